@@ -0,0 +1,193 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Errors returned by the BaseN codec.
+var (
+	ErrInvalidAlphabet  = errors.New("invalid alphabet: must contain at least 2 distinct characters")
+	ErrInvalidCharacter = errors.New("invalid character for alphabet")
+)
+
+// BaseN encodes byte slices as numbers written in an arbitrary alphabet, such as
+// AlphaNumeric for base62 or UppercaseAlphabet for base26.
+//
+// The input is treated as a single big-endian integer and converted to the base
+// given by the alphabet length, so the output is free of modulo bias and, unlike
+// the per-byte mapping used by Bytes, every input bit is kept and recoverable.
+// Leading zero bytes are encoded as the first character of the alphabet, one per
+// byte, so that Decode recovers the exact input.
+//
+// A BaseN is immutable and safe for concurrent use.
+type BaseN struct {
+	alphabet string
+	index    [256]int16
+}
+
+// NewBaseN creates a codec for the given alphabet.
+//
+// Parameters:
+//   - alphabet: the characters used as digits, in ascending order. Must contain
+//     at least 2 characters and no duplicates.
+//
+// Returns:
+//   - *BaseN: the codec.
+//   - error: ErrEmptyCharset if the alphabet is empty, or ErrInvalidAlphabet if it
+//     is too short or contains duplicates.
+func NewBaseN(alphabet string) (*BaseN, error) {
+	if alphabet == "" {
+		return nil, ErrEmptyCharset
+	}
+
+	if len(alphabet) < 2 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	codec := &BaseN{alphabet: alphabet}
+	for i := range codec.index {
+		codec.index[i] = -1
+	}
+
+	for i := range len(alphabet) {
+		if codec.index[alphabet[i]] >= 0 {
+			return nil, fmt.Errorf("%w: duplicate %q", ErrInvalidAlphabet, alphabet[i])
+		}
+
+		codec.index[alphabet[i]] = int16(i)
+	}
+
+	return codec, nil
+}
+
+// MustBaseN works like NewBaseN but panics on error instead of returning it.
+func MustBaseN(alphabet string) *BaseN {
+	codec, err := NewBaseN(alphabet)
+	if err != nil {
+		panic(err)
+	}
+
+	return codec
+}
+
+// Alphabet returns the characters used as digits.
+func (b *BaseN) Alphabet() string {
+	return b.alphabet
+}
+
+// Encode returns the encoding of src.
+func (b *BaseN) Encode(src []byte) string {
+	base := len(b.alphabet)
+
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+
+	// Every digit carries at least floor(log2(base)) bits, which bounds the
+	// number of digits needed.
+	digits := make([]byte, (len(src)-zeros)*8/(bits.Len(uint(base))-1)+1)
+	high := len(digits) - 1
+
+	for _, v := range src[zeros:] {
+		carry := int(v)
+
+		i := len(digits) - 1
+		for ; i > high || carry != 0; i-- {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % base)
+			carry /= base
+		}
+
+		high = i
+	}
+
+	start := 0
+	for start < len(digits) && digits[start] == 0 {
+		start++
+	}
+
+	out := make([]byte, zeros+len(digits)-start)
+	for i := range zeros {
+		out[i] = b.alphabet[0]
+	}
+
+	for i, d := range digits[start:] {
+		out[zeros+i] = b.alphabet[d]
+	}
+
+	return string(out)
+}
+
+// Decode returns the bytes represented by s.
+//
+// Returns:
+//   - []byte: the decoded bytes.
+//   - error: ErrInvalidCharacter if s contains a character outside the alphabet.
+func (b *BaseN) Decode(s string) ([]byte, error) {
+	base := len(b.alphabet)
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == b.alphabet[0] {
+		zeros++
+	}
+
+	// Every digit carries at most ceil(log2(base)) bits.
+	out := make([]byte, (len(s)-zeros)*bits.Len(uint(base-1))/8+1)
+	high := len(out) - 1
+
+	for pos := zeros; pos < len(s); pos++ {
+		digit := b.index[s[pos]]
+		if digit < 0 {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrInvalidCharacter, s[pos], pos)
+		}
+
+		carry := int(digit)
+
+		i := len(out) - 1
+		for ; i > high || carry != 0; i-- {
+			carry += int(out[i]) * base
+			out[i] = byte(carry)
+			carry >>= 8
+		}
+
+		high = i
+	}
+
+	start := 0
+	for start < len(out) && out[start] == 0 {
+		start++
+	}
+
+	decoded := make([]byte, zeros+len(out)-start)
+	copy(decoded[zeros:], out[start:])
+
+	return decoded, nil
+}
+
+// Token generates size bytes of cryptographically secure randomness and returns
+// them encoded with the codec, together with the raw bytes.
+//
+// Parameters:
+//   - size: the number of random bytes. Must be greater than 0.
+//
+// Returns:
+//   - string: the encoded token.
+//   - []byte: the raw random bytes the token encodes.
+//   - error: an error if random generation fails or if size is invalid.
+func (b *BaseN) Token(size int) (string, []byte, error) {
+	return b.TokenWithContext(context.Background(), size)
+}
+
+// TokenWithContext works like Token but accepts a context for cancellation support.
+func (b *BaseN) TokenWithContext(ctx context.Context, size int) (string, []byte, error) {
+	raw, err := defaultGenerator().read(ctx, size)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return b.Encode(raw), raw, nil
+}
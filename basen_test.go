@@ -0,0 +1,145 @@
+package strand_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBaseNRoundTrip verifies that Decode recovers the exact input for every
+// alphabet, including inputs with leading zero bytes.
+func TestBaseNRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	inputs := [][]byte{
+		{},
+		{0x00},
+		{0x00, 0x00, 0x00},
+		{0x01},
+		{0xff},
+		{0x00, 0x00, 0xde, 0xad, 0xbe, 0xef},
+		[]byte("Hello World!"),
+		bytes.Repeat([]byte{0xff}, 64),
+	}
+
+	for _, alphabet := range []string{
+		"01",
+		strand.Numbers,
+		strand.UppercaseAlphabet,
+		strand.AlphaNumeric,
+		strand.Base58Alphabet,
+		strand.ALL,
+	} {
+		codec := strand.MustBaseN(alphabet)
+		assert.Equal(t, alphabet, codec.Alphabet())
+
+		for _, input := range inputs {
+			encoded := codec.Encode(input)
+			assert.True(t, onlyContains(encoded, alphabet))
+
+			decoded, err := codec.Decode(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, input, decoded, "alphabet %q, input %x", alphabet, input)
+		}
+	}
+}
+
+// TestBaseNMatchesBigInt verifies the conversion against math/big for inputs
+// without leading zeros.
+func TestBaseNMatchesBigInt(t *testing.T) {
+	t.Parallel()
+
+	// big.Int.Text uses this digit order for bases up to 62.
+	const digits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	for _, base := range []int{2, 10, 16, 36, 62} {
+		codec := strand.MustBaseN(digits[:base])
+
+		for range 20 {
+			_, raw, err := strand.TokenBytes(24, strand.Hex)
+			require.NoError(t, err)
+
+			raw[0] |= 0x01 // Avoid leading zero bytes, which big.Int drops.
+
+			assert.Equal(t, new(big.Int).SetBytes(raw).Text(base), codec.Encode(raw))
+		}
+	}
+}
+
+// TestBaseNToken verifies that random tokens round-trip to their raw bytes.
+func TestBaseNToken(t *testing.T) {
+	t.Parallel()
+
+	codec := strand.MustBaseN(strand.UppercaseAlphabet)
+
+	token, raw, err := codec.Token(16)
+	require.NoError(t, err)
+	assert.Len(t, raw, 16)
+	assert.True(t, onlyContains(token, strand.UppercaseAlphabet))
+	// 128 bits need at most ceil(128 / log2(26)) = 28 characters.
+	assert.LessOrEqual(t, len(token), 28)
+
+	decoded, err := codec.Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, raw, decoded)
+
+	_, _, err = codec.Token(0)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = codec.TokenWithContext(ctx, 16)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestBaseNErrors verifies that invalid alphabets and inputs are rejected.
+func TestBaseNErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.NewBaseN("")
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.NewBaseN("a")
+	require.ErrorIs(t, err, strand.ErrInvalidAlphabet)
+
+	_, err = strand.NewBaseN("abca")
+	require.ErrorIs(t, err, strand.ErrInvalidAlphabet)
+
+	assert.Panics(t, func() {
+		strand.MustBaseN("")
+	})
+
+	_, err = strand.MustBaseN(strand.Numbers).Decode("12a4")
+	require.ErrorIs(t, err, strand.ErrInvalidCharacter)
+}
+
+// TestEncodingDecodeString verifies that every built-in encoding round-trips.
+func TestEncodingDecodeString(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte("\x00\x00strand")
+
+	for _, enc := range []strand.Encoding{
+		strand.Hex, strand.Base32, strand.Base32NoPadding, strand.Base64URL, strand.Base58, strand.Base62,
+	} {
+		decoded, err := enc.DecodeString(enc.EncodeToString(raw))
+		require.NoError(t, err)
+		assert.Equal(t, raw, decoded, enc.String())
+	}
+
+	_, err := strand.Hex.DecodeString("zz")
+	require.Error(t, err)
+
+	_, err = strand.Base58.DecodeString(strings.Repeat("0", 4))
+	require.ErrorIs(t, err, strand.ErrInvalidCharacter)
+
+	_, err = strand.Encoding(42).DecodeString("")
+	require.ErrorIs(t, err, strand.ErrInvalidEncoding)
+}
@@ -2,6 +2,7 @@ package strand_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 
 	"github.com/everlastingbeta/strand"
@@ -270,3 +271,126 @@ func BenchmarkMustString(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkPatternGenerate measures the performance of generating values
+// from a compiled Pattern with both the crypto and seeded sources.
+func BenchmarkPatternGenerate(b *testing.B) {
+	p := strand.MustCompilePattern("AAA-9999-aaaa-****", nil)
+
+	b.Run("Crypto", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			_, _ = p.Generate()
+		}
+	})
+
+	b.Run("Seeded", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			_ = p.SeededGenerate(42)
+		}
+	})
+}
+
+// BenchmarkIntRange measures the performance of drawing bounded integers with
+// both the crypto and seeded sources.
+func BenchmarkIntRange(b *testing.B) {
+	b.Run("Crypto", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			_, _ = strand.IntRange(100000, 999999)
+		}
+	})
+
+	b.Run("Seeded", func(b *testing.B) {
+		gen := strand.NewSeededGenerator(42)
+
+		b.ReportAllocs()
+
+		for range b.N {
+			_, _ = gen.IntRange(100000, 999999)
+		}
+	})
+}
+
+// BenchmarkPrefetcherNext measures the latency of taking prefetched values
+// compared to generating them on demand.
+func BenchmarkPrefetcherNext(b *testing.B) {
+	spec := strand.Spec{Size: 32, Charset: strand.AlphaNumeric}
+
+	b.Run("Prefetcher", func(b *testing.B) {
+		p, err := strand.NewPrefetcher(context.Background(), spec, strand.PrefetcherOptions{Capacity: 1024})
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		defer p.Close()
+
+		b.ReportAllocs()
+
+		for range b.N {
+			_, _ = p.Next()
+		}
+	})
+
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			_, _ = strand.String(spec.Size, spec.Charset)
+		}
+	})
+}
+
+// BenchmarkSeededParallel compares drawing from one shared seeded stream with
+// drawing from a substream per goroutine.
+func BenchmarkSeededParallel(b *testing.B) {
+	b.Run("Shared", func(b *testing.B) {
+		shared := strand.NewSharedSeededGenerator(42)
+
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = shared.Bytes(32, strand.AlphaNumeric)
+			}
+		})
+	})
+
+	b.Run("Substream", func(b *testing.B) {
+		var streams atomic.Uint64
+
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			gen := strand.NewSeededSubstream(42, streams.Add(1))
+
+			for pb.Next() {
+				_ = gen.Bytes(32, strand.AlphaNumeric)
+			}
+		})
+	})
+}
+
+// BenchmarkBatchParallel compares generating a large seeded batch on one
+// worker with spreading it across GOMAXPROCS workers.
+func BenchmarkBatchParallel(b *testing.B) {
+	spec := strand.Spec{Size: 32, Charset: strand.AlphaNumeric}
+
+	for _, bench := range []struct {
+		name    string
+		workers int
+	}{
+		{"Serial", 1},
+		{"Parallel", 0},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				_, _ = strand.SeededBatchParallel(context.Background(), 10_000, spec, bench.workers, 42)
+			}
+		})
+	}
+}
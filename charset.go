@@ -0,0 +1,47 @@
+package strand
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCharset is returned when a predefined charset is requested by a name
+// that does not exist.
+var ErrUnknownCharset = errors.New("unknown charset")
+
+// CharsetNames returns the names accepted by CharsetByName, in the order the
+// charsets are declared.
+func CharsetNames() []string {
+	return []string{"upper", "lower", "alpha", "numbers", "alphanumeric", "symbols", "all"}
+}
+
+// CharsetByName returns the predefined charset with the given name, so that
+// charsets can be selected from configuration files and command-line flags.
+//
+// Parameters:
+//   - name: one of the names returned by CharsetNames. Matching ignores case.
+//
+// Returns:
+//   - string: the charset.
+//   - error: ErrUnknownCharset if no charset has that name.
+func CharsetByName(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "upper":
+		return UppercaseAlphabet, nil
+	case "lower":
+		return LowercaseAlphabet, nil
+	case "alpha":
+		return Alphabet, nil
+	case "numbers":
+		return Numbers, nil
+	case "alphanumeric":
+		return AlphaNumeric, nil
+	case "symbols":
+		return Symbols, nil
+	case "all":
+		return ALL, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownCharset, name)
+	}
+}
@@ -0,0 +1,46 @@
+package strand_test
+
+import (
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCharsetByName verifies that every advertised name resolves and that
+// unknown names are rejected.
+func TestCharsetByName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string // Name to look up
+		want string // Expected charset
+	}{
+		{name: "upper", want: strand.UppercaseAlphabet},
+		{name: "lower", want: strand.LowercaseAlphabet},
+		{name: "alpha", want: strand.Alphabet},
+		{name: "numbers", want: strand.Numbers},
+		{name: "AlphaNumeric", want: strand.AlphaNumeric},
+		{name: "symbols", want: strand.Symbols},
+		{name: "ALL", want: strand.ALL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := strand.CharsetByName(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, name := range strand.CharsetNames() {
+		_, err := strand.CharsetByName(name)
+		require.NoError(t, err, name)
+	}
+
+	_, err := strand.CharsetByName("hex")
+	require.ErrorIs(t, err, strand.ErrUnknownCharset)
+}
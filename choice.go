@@ -0,0 +1,165 @@
+package strand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrEmptyItems is returned when an element is requested from an empty slice.
+var ErrEmptyItems = errors.New("invalid items: cannot be empty")
+
+// Choice returns an element of items chosen uniformly at random using a
+// cryptographically secure source.
+//
+// Parameters:
+//   - items: the elements to choose from. Cannot be empty.
+//
+// Returns:
+//   - T: the chosen element.
+//   - error: ErrEmptyItems if items is empty, or an error if random generation fails.
+func Choice[T any](items []T) (T, error) {
+	return choose(newCryptoSource(), items)
+}
+
+// Sample returns k distinct elements of items, chosen uniformly at random
+// without replacement using a cryptographically secure source. Every subset and
+// order is equally likely. items is not modified.
+//
+// Parameters:
+//   - items: the elements to choose from.
+//   - k: the number of elements to return, from 0 up to len(items).
+//
+// Returns:
+//   - []T: the chosen elements, in random order.
+//   - error: ErrInvalidSize if k is out of range, or an error if random
+//     generation fails.
+func Sample[T any](items []T, k int) ([]T, error) {
+	return sample(newCryptoSource(), items, k)
+}
+
+// Shuffle randomly permutes items in place with the Fisher-Yates algorithm
+// using a cryptographically secure source, so every permutation is equally likely.
+//
+// Returns an error if random generation fails, in which case items may be
+// partially shuffled.
+func Shuffle[T any](items []T) error {
+	return shuffle(newCryptoSource(), items)
+}
+
+// WeightedChoice returns an element of items chosen with probability
+// proportional to its weight using a cryptographically secure source.
+//
+// Parameters:
+//   - items: the elements to choose from. Cannot be empty.
+//   - weights: the relative weight of each element, in the same order as items.
+//     Weights must be finite, non-negative and not all zero.
+//
+// Returns:
+//   - T: the chosen element.
+//   - error: ErrEmptyItems if items is empty, ErrInvalidWeight if the weights
+//     are invalid or do not match items, or an error if random generation fails.
+func WeightedChoice[T any](items []T, weights []float64) (T, error) {
+	return weightedChoose(newCryptoSource(), items, weights)
+}
+
+// choose returns an element of items drawn from src.
+func choose[T any](src source, items []T) (T, error) {
+	var zero T
+
+	if len(items) == 0 {
+		return zero, ErrEmptyItems
+	}
+
+	i, err := src.intN(len(items))
+	if err != nil {
+		return zero, err
+	}
+
+	return items[i], nil
+}
+
+// sample returns k elements of items drawn from src without replacement, by
+// running the first k steps of a Fisher-Yates shuffle on a copy of items.
+func sample[T any](src source, items []T, k int) ([]T, error) {
+	if k < 0 || k > len(items) {
+		return nil, fmt.Errorf("%w: sample size %d is not between 0 and %d", ErrInvalidSize, k, len(items))
+	}
+
+	pool := append([]T(nil), items...)
+
+	for i := range k {
+		j, err := src.intN(len(pool) - i)
+		if err != nil {
+			return nil, err
+		}
+
+		pool[i], pool[i+j] = pool[i+j], pool[i]
+	}
+
+	return pool[:k:k], nil
+}
+
+// shuffle permutes items in place with swaps drawn from src.
+func shuffle[T any](src source, items []T) error {
+	for i := len(items) - 1; i > 0; i-- {
+		j, err := src.intN(i + 1)
+		if err != nil {
+			return err
+		}
+
+		items[i], items[j] = items[j], items[i]
+	}
+
+	return nil
+}
+
+// weightedChoose returns an element of items drawn from src with probability
+// proportional to its weight.
+func weightedChoose[T any](src source, items []T, weights []float64) (T, error) {
+	var zero T
+
+	if len(items) == 0 {
+		return zero, ErrEmptyItems
+	}
+
+	if len(weights) != len(items) {
+		return zero, fmt.Errorf("%w: got %d weights for %d items", ErrInvalidWeight, len(weights), len(items))
+	}
+
+	total := 0.0
+	last := -1
+
+	for i, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return zero, fmt.Errorf("%w: %v at index %d", ErrInvalidWeight, weight, i)
+		}
+
+		if weight > 0 {
+			total += weight
+			last = i
+		}
+	}
+
+	if last < 0 || math.IsInf(total, 0) {
+		return zero, ErrInvalidWeight
+	}
+
+	u, err := src.float64()
+	if err != nil {
+		return zero, err
+	}
+
+	target := u * total
+	for i, weight := range weights {
+		if target < weight {
+			return items[i], nil
+		}
+
+		target -= weight
+	}
+
+	// Rounding can leave target just above the remaining weight; the draw then
+	// belongs to the last element that can be chosen.
+	return items[last], nil
+}
@@ -0,0 +1,182 @@
+package strand_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChoice verifies that every element is chosen equally often.
+func TestChoice(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+	observed := make([]int, len(items))
+	expected := make([]float64, len(items))
+
+	const draws = 70_000
+
+	for range draws {
+		item, err := strand.Choice(items)
+		require.NoError(t, err)
+
+		observed[slices.Index(items, item)]++
+	}
+
+	for i := range expected {
+		expected[i] = draws / float64(len(items))
+	}
+
+	result, err := stats.ChiSquared(observed, expected)
+	require.NoError(t, err)
+	assert.True(t, result.Pass(qualityAlpha), result.String())
+
+	_, err = strand.Choice([]int{})
+	require.ErrorIs(t, err, strand.ErrEmptyItems)
+}
+
+// TestSample verifies that samples are distinct elements of the input and that
+// the input is left untouched.
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	original := slices.Clone(items)
+
+	for _, k := range []int{0, 1, 5, 10} {
+		picked, err := strand.Sample(items, k)
+		require.NoError(t, err)
+		assert.Len(t, picked, k)
+
+		sorted := slices.Clone(picked)
+		slices.Sort(sorted)
+		assert.Len(t, slices.Compact(sorted), k, "sampled elements should be distinct")
+
+		for _, v := range picked {
+			assert.Contains(t, items, v)
+		}
+	}
+
+	assert.Equal(t, original, items)
+
+	_, err := strand.Sample(items, 11)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.Sample(items, -1)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+}
+
+// TestShuffle verifies that shuffling permutes the elements and reaches every
+// position.
+func TestShuffle(t *testing.T) {
+	t.Parallel()
+
+	firsts := make(map[int]bool)
+
+	for range 200 {
+		items := []int{0, 1, 2, 3, 4}
+		require.NoError(t, strand.Shuffle(items))
+
+		sorted := slices.Clone(items)
+		slices.Sort(sorted)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, sorted)
+
+		firsts[items[0]] = true
+	}
+
+	assert.Len(t, firsts, 5, "every element should reach the first position")
+	require.NoError(t, strand.Shuffle([]int{}))
+}
+
+// TestWeightedChoice verifies that elements are chosen in proportion to their
+// weights and that zero weights are never chosen.
+func TestWeightedChoice(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"rare", "never", "common"}
+	weights := []float64{1, 0, 3}
+	counts := make(map[string]int)
+
+	const draws = 40_000
+
+	for range draws {
+		item, err := strand.WeightedChoice(items, weights)
+		require.NoError(t, err)
+
+		counts[item]++
+	}
+
+	assert.Zero(t, counts["never"])
+	assert.InDelta(t, 0.75, float64(counts["common"])/draws, 0.02)
+
+	tests := []struct {
+		name    string    // Description of the test case
+		items   []string  // Items under test
+		weights []float64 // Weights under test
+		want    error     // Expected error
+	}{
+		{name: "no items", items: nil, weights: nil, want: strand.ErrEmptyItems},
+		{name: "mismatched lengths", items: []string{"a", "b"}, weights: []float64{1}, want: strand.ErrInvalidWeight},
+		{name: "negative weight", items: []string{"a", "b"}, weights: []float64{1, -1}, want: strand.ErrInvalidWeight},
+		{name: "NaN weight", items: []string{"a"}, weights: []float64{math.NaN()}, want: strand.ErrInvalidWeight},
+		{name: "all zero", items: []string{"a", "b"}, weights: []float64{0, 0}, want: strand.ErrInvalidWeight},
+		{name: "overflowing total", items: []string{"a", "b"}, weights: []float64{math.MaxFloat64, math.MaxFloat64}, want: strand.ErrInvalidWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := strand.WeightedChoice(tt.items, tt.weights)
+			require.ErrorIs(t, err, tt.want)
+
+			_, err = strand.SeededWeightedChoice(tt.items, tt.weights, 1)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+// TestSeededSelection verifies that the seeded helpers are deterministic.
+func TestSeededSelection(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	first, err := strand.SeededChoice(items, 42)
+	require.NoError(t, err)
+
+	again, err := strand.SeededChoice(items, 42)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	sampled, err := strand.SeededSample(items, 4, 42)
+	require.NoError(t, err)
+
+	resampled, err := strand.SeededSample(items, 4, 42)
+	require.NoError(t, err)
+	assert.Equal(t, sampled, resampled)
+
+	shuffled, reshuffled := slices.Clone(items), slices.Clone(items)
+	strand.SeededShuffle(shuffled, 42)
+	strand.SeededShuffle(reshuffled, 42)
+	assert.Equal(t, shuffled, reshuffled)
+	assert.NotEqual(t, items, shuffled)
+
+	weighted, err := strand.SeededWeightedChoice(items, []float64{1, 1, 1, 1, 1, 1, 1, 1}, 42)
+	require.NoError(t, err)
+
+	reweighted, err := strand.SeededWeightedChoice(items, []float64{1, 1, 1, 1, 1, 1, 1, 1}, 42)
+	require.NoError(t, err)
+	assert.Equal(t, weighted, reweighted)
+
+	_, err = strand.SeededChoice([]string{}, 1)
+	require.ErrorIs(t, err, strand.ErrEmptyItems)
+
+	_, err = strand.SeededSample(items, 9, 1)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+}
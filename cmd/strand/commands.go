@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/everlastingbeta/strand"
+)
+
+// Defaults for the generating commands.
+const (
+	defaultStringLength   = 16
+	defaultPasswordLength = 20
+	defaultTokenBytes     = 32
+	defaultSeparator      = "-"
+)
+
+var (
+	// errHelp is returned when a command's help was requested and printed.
+	errHelp = errors.New("help requested")
+
+	// errFlags marks usage errors that the flag package has already explained.
+	errFlags = errors.New("invalid flags")
+)
+
+// output holds the flags shared by every generating command.
+type output struct {
+	count  int
+	format string
+	seed   int64
+	seeded bool
+}
+
+// register adds the shared flags to fs.
+func (o *output) register(fs *flag.FlagSet, seedable bool) {
+	fs.IntVar(&o.count, "count", 1, "number of values to generate")
+	fs.StringVar(&o.format, "format", "plain", "output format: plain, json or nul")
+
+	if seedable {
+		fs.Func("seed", "generate reproducible, NOT cryptographically secure, output from this seed", func(s string) error {
+			seed, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return errors.New("seed must be an integer")
+			}
+
+			o.seed, o.seeded = seed, true
+
+			return nil
+		})
+	}
+}
+
+// generate calls next count times and writes the results to w in the selected
+// format. In seeded mode every call receives the same SeededGenerator, so the
+// values continue one reproducible sequence; otherwise it receives nil.
+func (o *output) generate(w io.Writer, next func(gen *strand.SeededGenerator) (string, error)) error {
+	if o.count <= 0 {
+		return fmt.Errorf("%w: count must be greater than 0", strand.ErrInvalidSize)
+	}
+
+	if o.format != "plain" && o.format != "json" && o.format != "nul" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, o.format)
+	}
+
+	var gen *strand.SeededGenerator
+	if o.seeded {
+		gen = strand.NewSeededGenerator(o.seed)
+	}
+
+	values := make([]string, o.count)
+	for i := range values {
+		value, err := next(gen)
+		if err != nil {
+			return err
+		}
+
+		values[i] = value
+	}
+
+	var err error
+
+	switch o.format {
+	case "json":
+		err = json.NewEncoder(w).Encode(values)
+	case "nul":
+		_, err = io.WriteString(w, strings.Join(values, "\x00")+"\x00")
+	default:
+		_, err = io.WriteString(w, strings.Join(values, "\n")+"\n")
+	}
+
+	if err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	return nil
+}
+
+// parse parses args into fs and converts flag errors into the command's errors.
+func parse(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return errHelp
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, errFlags)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, fs.Arg(0))
+	}
+
+	return nil
+}
+
+// runString implements the string and password commands, which differ only in
+// their defaults.
+func runString(ctx context.Context, name string, args []string, length int, charsetName string, stdout, stderr io.Writer) error {
+	var (
+		out     output
+		literal string
+	)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&length, "length", length, "number of characters")
+	fs.StringVar(&charsetName, "charset", charsetName, "predefined charset: "+strings.Join(strand.CharsetNames(), ", "))
+	fs.StringVar(&literal, "chars", "", "literal characters to use instead of a predefined charset")
+	out.register(fs, true)
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	charset, err := resolveCharset(fs, charsetName, literal)
+	if err != nil {
+		return err
+	}
+
+	return out.generate(stdout, func(gen *strand.SeededGenerator) (string, error) {
+		if gen == nil {
+			return strand.StringWithContext(ctx, length, charset)
+		}
+
+		// SeededGenerator does not validate its arguments, so check them here
+		// to report the same errors as the secure path.
+		switch {
+		case length <= 0:
+			return "", strand.ErrInvalidSize
+		case charset == "":
+			return "", strand.ErrEmptyCharset
+		case ctx.Err() != nil:
+			return "", ctx.Err()
+		default:
+			return gen.String(length, charset), nil
+		}
+	})
+}
+
+// resolveCharset returns the literal charset if -chars was given, and the named
+// predefined charset otherwise.
+func resolveCharset(fs *flag.FlagSet, name, literal string) (string, error) {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if !set["chars"] {
+		charset, err := strand.CharsetByName(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", errUsage, err)
+		}
+
+		return charset, nil
+	}
+
+	if set["charset"] {
+		return "", fmt.Errorf("%w: -charset and -chars cannot be combined", errUsage)
+	}
+
+	return literal, nil
+}
+
+// runPassphrase implements the passphrase command.
+func runPassphrase(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		out       output
+		words     int
+		separator string
+	)
+
+	fs := flag.NewFlagSet("passphrase", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&words, "words", strand.DefaultPassphraseWords, "number of words")
+	fs.StringVar(&separator, "separator", defaultSeparator, "string placed between words")
+	out.register(fs, true)
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	return out.generate(stdout, func(gen *strand.SeededGenerator) (string, error) {
+		if gen != nil {
+			return gen.Passphrase(words, separator)
+		}
+
+		return strand.PassphraseWithContext(ctx, words, separator)
+	})
+}
+
+// runToken implements the token command. Tokens are secrets, so it has no
+// seeded mode.
+func runToken(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		out      output
+		size     int
+		encoding string
+	)
+
+	fs := flag.NewFlagSet("token", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.IntVar(&size, "length", defaultTokenBytes, "number of random bytes")
+	fs.StringVar(&encoding, "encoding", strand.Base64URL.String(), "encoding: hex, base32, base32-nopad, base64url, base58 or base62")
+	out.register(fs, false)
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	enc, err := strand.ParseEncoding(encoding)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return out.generate(stdout, func(*strand.SeededGenerator) (string, error) {
+		return strand.TokenWithContext(ctx, size, enc)
+	})
+}
+
+// runCharsets implements the charsets command.
+func runCharsets(stdout io.Writer) error {
+	for _, name := range strand.CharsetNames() {
+		charset, err := strand.CharsetByName(name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(stdout, "%-13s %s\n", name, charset); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// exitCode maps err to the exit status of the command.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, strand.ErrInvalidSize):
+		return exitInvalidSize
+	case errors.Is(err, strand.ErrEmptyCharset):
+		return exitEmptyCharset
+	default:
+		return exitFailure
+	}
+}
@@ -0,0 +1,114 @@
+// Command strand generates random strings, passwords, passphrases and encoded
+// tokens from the command line.
+//
+// Usage:
+//
+//	strand <command> [flags]
+//
+// The commands are:
+//
+//	string      random string from a charset (default 16 alphanumeric characters)
+//	password    random password (default 20 characters from all charsets)
+//	passphrase  random words (default 8 words joined by "-")
+//	token       encoded random bytes (default 32 bytes as base64url)
+//	charsets    list the predefined charsets
+//	serve       serve the generating commands over an HTTP/JSON API
+//
+// Every generating command accepts -count and -format, and every command except
+// token accepts -seed for reproducible, NOT cryptographically secure, output.
+//
+// The exit status is 0 on success, 1 if generation fails, 2 for invalid usage,
+// 3 for an invalid size or count (strand.ErrInvalidSize) and 4 for an empty
+// charset (strand.ErrEmptyCharset).
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// Exit statuses reported by the command.
+const (
+	exitOK           = 0
+	exitFailure      = 1
+	exitUsage        = 2
+	exitInvalidSize  = 3
+	exitEmptyCharset = 4
+)
+
+// errUsage marks errors caused by invalid command-line usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command given by args and returns the exit status.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+
+		return exitUsage
+	}
+
+	var err error
+
+	switch name, rest := args[0], args[1:]; name {
+	case "string":
+		err = runString(ctx, name, rest, defaultStringLength, "alphanumeric", stdout, stderr)
+	case "password":
+		err = runString(ctx, name, rest, defaultPasswordLength, "all", stdout, stderr)
+	case "passphrase":
+		err = runPassphrase(ctx, rest, stdout, stderr)
+	case "token":
+		err = runToken(ctx, rest, stdout, stderr)
+	case "charsets":
+		err = runCharsets(stdout)
+	case "serve":
+		err = runServe(ctx, rest, stderr)
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "strand: unknown command %q\n", name)
+		usage(stderr)
+
+		return exitUsage
+	}
+
+	if errors.Is(err, errHelp) {
+		return exitOK
+	}
+
+	// The flag package has already explained invalid flags.
+	if err != nil && !errors.Is(err, errFlags) {
+		fmt.Fprintln(stderr, "strand:", err)
+	}
+
+	return exitCode(err)
+}
+
+// usage writes the command summary to w.
+func usage(w io.Writer) {
+	fmt.Fprint(w, `usage: strand <command> [flags]
+
+commands:
+  string      random string from a charset
+  password    random password
+  passphrase  random words
+  token       encoded random bytes
+  charsets    list the predefined charsets
+  serve       serve the generating commands over an HTTP/JSON API
+
+Run "strand <command> -h" for the flags of a command.
+`)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// execute runs the command with args and returns its exit status and output.
+func execute(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+// TestCommands verifies the output shape of every generating command.
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string   // Description of the test case
+		args    []string // Command line
+		lines   int      // Expected number of output lines
+		charset string   // Characters every line must be drawn from
+		length  int      // Expected length of every line, or 0 to skip
+	}{
+		{
+			name:    "string defaults",
+			args:    []string{"string"},
+			lines:   1,
+			charset: strand.AlphaNumeric,
+			length:  defaultStringLength,
+		},
+		{
+			name:    "string with named charset",
+			args:    []string{"string", "-length", "8", "-charset", "numbers", "-count", "5"},
+			lines:   5,
+			charset: strand.Numbers,
+			length:  8,
+		},
+		{
+			name:    "string with literal charset",
+			args:    []string{"string", "-chars", "xyz", "-length", "30"},
+			lines:   1,
+			charset: "xyz",
+			length:  30,
+		},
+		{
+			name:    "password defaults",
+			args:    []string{"password", "-count", "3"},
+			lines:   3,
+			charset: strand.ALL,
+			length:  defaultPasswordLength,
+		},
+		{
+			name:    "passphrase",
+			args:    []string{"passphrase", "-words", "4", "-separator", "."},
+			lines:   1,
+			charset: strand.LowercaseAlphabet + ".",
+		},
+		{
+			name:    "hex token",
+			args:    []string{"token", "-length", "16", "-encoding", "hex", "-count", "2"},
+			lines:   2,
+			charset: "0123456789abcdef",
+			length:  32,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, stdout, stderr := execute(t, tt.args...)
+			require.Equal(t, exitOK, code, stderr)
+
+			lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+			require.Len(t, lines, tt.lines)
+
+			for _, line := range lines {
+				if tt.length > 0 {
+					assert.Len(t, line, tt.length)
+				}
+
+				for _, c := range line {
+					assert.Contains(t, tt.charset, string(c))
+				}
+			}
+		})
+	}
+}
+
+// TestFormats verifies JSON and NUL-separated output.
+func TestFormats(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := execute(t, "string", "-count", "3", "-format", "json")
+	require.Equal(t, exitOK, code)
+
+	var values []string
+	require.NoError(t, json.Unmarshal([]byte(stdout), &values))
+	assert.Len(t, values, 3)
+
+	code, stdout, _ = execute(t, "token", "-count", "2", "-format", "nul")
+	require.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSuffix(stdout, "\x00"), "\x00"), 2)
+	assert.True(t, strings.HasSuffix(stdout, "\x00"))
+}
+
+// TestSeed verifies that seeded output is reproducible and varies per value.
+func TestSeed(t *testing.T) {
+	t.Parallel()
+
+	for _, command := range []string{"string", "password", "passphrase"} {
+		_, first, _ := execute(t, command, "-seed", "42", "-count", "2")
+		_, second, _ := execute(t, command, "-seed", "42", "-count", "2")
+		assert.Equal(t, first, second, command)
+
+		lines := strings.Split(strings.TrimSuffix(first, "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.NotEqual(t, lines[0], lines[1], command)
+	}
+
+	// Neighbouring seeds start unrelated sequences instead of overlapping.
+	_, one, _ := execute(t, "string", "-seed", "1", "-count", "3")
+	_, two, _ := execute(t, "string", "-seed", "2", "-count", "3")
+
+	for _, value := range strings.Fields(one) {
+		assert.NotContains(t, strings.Fields(two), value)
+	}
+
+	code, _, _ := execute(t, "token", "-seed", "42")
+	assert.Equal(t, exitUsage, code, "tokens have no seeded mode")
+}
+
+// TestExitCodes verifies that failures map to the documented exit statuses.
+func TestExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string   // Description of the test case
+		args []string // Command line
+		want int      // Expected exit status
+	}{
+		{name: "no command", args: nil, want: exitUsage},
+		{name: "unknown command", args: []string{"uuid"}, want: exitUsage},
+		{name: "help", args: []string{"help"}, want: exitOK},
+		{name: "command help", args: []string{"string", "-h"}, want: exitOK},
+		{name: "unknown flag", args: []string{"string", "-size", "3"}, want: exitUsage},
+		{name: "unknown charset", args: []string{"string", "-charset", "hex"}, want: exitUsage},
+		{name: "charset and chars", args: []string{"string", "-charset", "all", "-chars", "ab"}, want: exitUsage},
+		{name: "unknown format", args: []string{"string", "-format", "csv"}, want: exitUsage},
+		{name: "unknown encoding", args: []string{"token", "-encoding", "base16"}, want: exitUsage},
+		{name: "invalid seed", args: []string{"string", "-seed", "12abc"}, want: exitUsage},
+		{name: "zero length", args: []string{"string", "-length", "0"}, want: exitInvalidSize},
+		{name: "zero length seeded", args: []string{"string", "-length", "0", "-seed", "1"}, want: exitInvalidSize},
+		{name: "zero count", args: []string{"password", "-count", "0"}, want: exitInvalidSize},
+		{name: "zero words", args: []string{"passphrase", "-words", "0"}, want: exitInvalidSize},
+		{name: "zero token bytes", args: []string{"token", "-length", "0"}, want: exitInvalidSize},
+		{name: "empty chars", args: []string{"string", "-chars", ""}, want: exitEmptyCharset},
+		{name: "empty chars seeded", args: []string{"string", "-chars", "", "-seed", "1"}, want: exitEmptyCharset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, _, _ := execute(t, tt.args...)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+// TestCharsets verifies that every predefined charset is listed.
+func TestCharsets(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := execute(t, "charsets")
+	require.Equal(t, exitOK, code)
+
+	for _, name := range strand.CharsetNames() {
+		assert.Contains(t, stdout, name)
+	}
+}
+
+// TestCanceledContext verifies that interrupted generation fails.
+func TestCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitFailure, run(ctx, []string{"string"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "context canceled")
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+)
+
+// Defaults for the serve command.
+const (
+	defaultAddr     = "127.0.0.1:8080"
+	defaultMaxSize  = 4096
+	defaultMaxCount = 100
+	defaultMaxBody  = 64 << 10
+
+	// shutdownTimeout bounds how long in-flight requests may run after the
+	// server is asked to stop.
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	// errBadRequest is returned for requests that cannot be parsed.
+	errBadRequest = errors.New("invalid request")
+
+	// errLimitExceeded is returned for requests asking for more than the
+	// server allows. Bodies over the limit also wrap *http.MaxBytesError.
+	errLimitExceeded = errors.New("request limit exceeded")
+)
+
+// limits bounds the work a single request may cause.
+type limits struct {
+	maxSize  int   // Largest size, in characters, bytes or words, of one value
+	maxCount int   // Largest number of values in one batch
+	maxBody  int64 // Largest request body, in bytes
+}
+
+// spec describes one value to generate. It is decoded from the query string of
+// the single-value endpoints and from the items of a batch request.
+type spec struct {
+	Kind      string  `json:"kind"`
+	Size      *int    `json:"size"`
+	Charset   string  `json:"charset"`
+	Chars     *string `json:"chars"`
+	Separator *string `json:"separator"`
+	Encoding  string  `json:"encoding"`
+}
+
+// batchRequest is the body of a batch request.
+type batchRequest struct {
+	Requests []spec `json:"requests"`
+}
+
+// statusBody is the body of the health check response.
+type statusBody struct {
+	Status string `json:"status"`
+}
+
+// valueBody is the body of a single value response.
+type valueBody struct {
+	Value string `json:"value"`
+}
+
+// valuesBody is the body of a batch response.
+type valuesBody struct {
+	Values []string `json:"values"`
+}
+
+// errorBody is the body of every error response. Code is stable and mirrors the
+// sentinel error that caused the failure, while Message is meant for humans.
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// runServe implements the serve command.
+func runServe(ctx context.Context, args []string, stderr io.Writer) error {
+	var (
+		addr string
+		lim  limits
+	)
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&addr, "addr", defaultAddr, "address to listen on")
+	fs.IntVar(&lim.maxSize, "max-size", defaultMaxSize, "largest size of a single value")
+	fs.IntVar(&lim.maxCount, "max-count", defaultMaxCount, "largest number of values in a batch")
+	fs.Int64Var(&lim.maxBody, "max-body", defaultMaxBody, "largest request body in bytes")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if lim.maxSize <= 0 || lim.maxCount <= 0 || lim.maxBody <= 0 {
+		return fmt.Errorf("%w: limits must be greater than 0", errUsage)
+	}
+
+	ln, err := new(net.ListenConfig).Listen(context.WithoutCancel(ctx), "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           newHandler(lim),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		MaxHeaderBytes:    16 << 10,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	fmt.Fprintln(stderr, "strand: listening on", ln.Addr())
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+
+	select {
+	case err := <-done:
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutdown: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// newHandler returns the HTTP API:
+//
+//	GET  /healthz          liveness check
+//	GET  /v1/string        ?size=&charset= or &chars=
+//	GET  /v1/password      ?size=&charset= or &chars=
+//	GET  /v1/passphrase    ?size= (words) &separator=
+//	GET  /v1/token         ?size= (bytes) &encoding=
+//	POST /v1/batch         {"requests": [{"kind": "string", ...}, ...]}
+//
+// Single values are returned as {"value": "..."} and batches as
+// {"values": [...]}, in request order.
+func newHandler(lim limits) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if allowMethod(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, statusBody{Status: "ok"})
+		}
+	})
+
+	for _, kind := range []string{"string", "password", "passphrase", "token"} {
+		mux.HandleFunc("/v1/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			if !allowMethod(w, r, http.MethodGet) {
+				return
+			}
+
+			s, err := specFromQuery(kind, r)
+			if err != nil {
+				writeError(w, err)
+
+				return
+			}
+
+			value, err := generateSpec(r.Context(), s, lim)
+			if err != nil {
+				writeError(w, err)
+
+				return
+			}
+
+			writeJSON(w, http.StatusOK, valueBody{Value: value})
+		})
+	}
+
+	mux.HandleFunc("/v1/batch", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+
+		values, err := generateBatch(r, lim)
+		if err != nil {
+			writeError(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, valuesBody{Values: values})
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorStatus(w, http.StatusNotFound, "not_found", "no endpoint at "+r.URL.Path)
+	})
+
+	return mux
+}
+
+// specFromQuery builds the spec of a single-value request from its query string.
+func specFromQuery(kind string, r *http.Request) (spec, error) {
+	query := r.URL.Query()
+	s := spec{Kind: kind, Charset: query.Get("charset"), Encoding: query.Get("encoding")}
+
+	if query.Has("size") {
+		size, err := strconv.Atoi(query.Get("size"))
+		if err != nil {
+			return s, fmt.Errorf("%w: size must be an integer", errBadRequest)
+		}
+
+		s.Size = &size
+	}
+
+	if query.Has("chars") {
+		chars := query.Get("chars")
+		s.Chars = &chars
+	}
+
+	if query.Has("separator") {
+		separator := query.Get("separator")
+		s.Separator = &separator
+	}
+
+	return s, nil
+}
+
+// generateBatch decodes a batch request and generates every value in it.
+func generateBatch(r *http.Request, lim limits) ([]string, error) {
+	var req batchRequest
+
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, lim.maxBody))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: body exceeds %d bytes: %w", errLimitExceeded, lim.maxBody, tooLarge)
+		}
+
+		return nil, fmt.Errorf("%w: %w", errBadRequest, err)
+	}
+
+	switch {
+	case len(req.Requests) == 0:
+		return nil, fmt.Errorf("%w: requests cannot be empty", strand.ErrInvalidSize)
+	case len(req.Requests) > lim.maxCount:
+		return nil, fmt.Errorf("%w: at most %d requests per batch", errLimitExceeded, lim.maxCount)
+	}
+
+	values := make([]string, len(req.Requests))
+	for i, s := range req.Requests {
+		value, err := generateSpec(r.Context(), s, lim)
+		if err != nil {
+			return nil, fmt.Errorf("request %d: %w", i, err)
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// generateSpec generates the value described by s, applying the same defaults
+// as the corresponding command.
+func generateSpec(ctx context.Context, s spec, lim limits) (string, error) {
+	if s.Size != nil && *s.Size > lim.maxSize {
+		return "", fmt.Errorf("%w: size cannot exceed %d", errLimitExceeded, lim.maxSize)
+	}
+
+	switch s.Kind {
+	case "string", "password":
+		length, name := defaultStringLength, "alphanumeric"
+		if s.Kind == "password" {
+			length, name = defaultPasswordLength, "all"
+		}
+
+		charset, err := specCharset(s, name)
+		if err != nil {
+			return "", err
+		}
+
+		return strand.StringWithContext(ctx, sizeOr(s.Size, length), charset)
+	case "passphrase":
+		separator := defaultSeparator
+		if s.Separator != nil {
+			separator = *s.Separator
+		}
+
+		return strand.PassphraseWithContext(ctx, sizeOr(s.Size, strand.DefaultPassphraseWords), separator)
+	case "token":
+		name := s.Encoding
+		if name == "" {
+			name = strand.Base64URL.String()
+		}
+
+		enc, err := strand.ParseEncoding(name)
+		if err != nil {
+			return "", err
+		}
+
+		return strand.TokenWithContext(ctx, sizeOr(s.Size, defaultTokenBytes), enc)
+	default:
+		return "", fmt.Errorf("%w: unknown kind %q", errBadRequest, s.Kind)
+	}
+}
+
+// specCharset returns the literal charset of s if it has one, and the named
+// predefined charset otherwise.
+func specCharset(s spec, name string) (string, error) {
+	if s.Chars != nil {
+		if s.Charset != "" {
+			return "", fmt.Errorf("%w: charset and chars cannot be combined", errBadRequest)
+		}
+
+		return *s.Chars, nil
+	}
+
+	if s.Charset != "" {
+		name = s.Charset
+	}
+
+	return strand.CharsetByName(name)
+}
+
+// sizeOr returns size, or fallback if size was not given. Explicit sizes below
+// one are passed through so the library reports them.
+func sizeOr(size *int, fallback int) int {
+	if size == nil {
+		return fallback
+	}
+
+	return *size
+}
+
+// allowMethod reports whether r uses method, and writes a 405 response if not.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+
+	w.Header().Set("Allow", method)
+	writeErrorStatus(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed")
+
+	return false
+}
+
+// writeError writes the response for err, using the code of the sentinel error
+// it wraps. Unexpected errors are reported without their details.
+func writeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &tooLarge):
+		writeErrorStatus(w, http.StatusRequestEntityTooLarge, "limit_exceeded", err.Error())
+	case errors.Is(err, strand.ErrInvalidSize):
+		writeErrorStatus(w, http.StatusBadRequest, "invalid_size", err.Error())
+	case errors.Is(err, strand.ErrEmptyCharset):
+		writeErrorStatus(w, http.StatusBadRequest, "empty_charset", err.Error())
+	case errors.Is(err, strand.ErrUnknownCharset):
+		writeErrorStatus(w, http.StatusBadRequest, "unknown_charset", err.Error())
+	case errors.Is(err, strand.ErrInvalidEncoding):
+		writeErrorStatus(w, http.StatusBadRequest, "invalid_encoding", err.Error())
+	case errors.Is(err, errBadRequest):
+		writeErrorStatus(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, errLimitExceeded):
+		writeErrorStatus(w, http.StatusBadRequest, "limit_exceeded", err.Error())
+	case errors.Is(err, strand.ErrRandomFailure):
+		writeErrorStatus(w, http.StatusInternalServerError, "random_failure", strand.ErrRandomFailure.Error())
+	default:
+		writeErrorStatus(w, http.StatusInternalServerError, "internal_error", "internal error")
+	}
+}
+
+// writeErrorStatus writes an error response.
+func writeErrorStatus(w http.ResponseWriter, status int, code, message string) {
+	var body errorBody
+	body.Error.Code = code
+	body.Error.Message = message
+
+	writeJSON(w, status, body)
+}
+
+// responseBody lists the bodies writeJSON accepts. They hold only strings, so
+// encoding them cannot fail.
+type responseBody interface {
+	statusBody | valueBody | valuesBody | errorBody
+}
+
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON[T responseBody](w http.ResponseWriter, status int, v T) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	// Only the write to the client can fail, after the status has been sent,
+	// so there is no way left to report it.
+	_ = json.NewEncoder(w).Encode(v) //nolint:errchkjson // Bodies hold only strings; a failed write cannot be reported.
+}
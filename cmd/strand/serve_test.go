@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLimits are small limits that make the limit checks easy to trigger.
+func testLimits() limits {
+	return limits{maxSize: 64, maxCount: 3, maxBody: 512}
+}
+
+// call performs a request against the API and decodes the JSON response.
+func call(t *testing.T, method, target, body string) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	newHandler(testLimits()).ServeHTTP(rec, req)
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+
+	return rec.Code, decoded
+}
+
+// TestServeValues verifies the single-value endpoints.
+func TestServeValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string // Description of the test case
+		target  string // Request URL
+		charset string // Characters the value must be drawn from
+		length  int    // Expected length of the value, or 0 to skip
+	}{
+		{
+			name:    "string",
+			target:  "/v1/string?size=32&charset=alphanumeric",
+			charset: strand.AlphaNumeric,
+			length:  32,
+		},
+		{
+			name:    "string with literal charset",
+			target:  "/v1/string?size=10&chars=ab",
+			charset: "ab",
+			length:  10,
+		},
+		{
+			name:    "password defaults",
+			target:  "/v1/password",
+			charset: strand.ALL,
+			length:  defaultPasswordLength,
+		},
+		{
+			name:    "passphrase",
+			target:  "/v1/passphrase?size=3&separator=_",
+			charset: strand.LowercaseAlphabet + "_",
+		},
+		{
+			name:    "hex token",
+			target:  "/v1/token?size=8&encoding=hex",
+			charset: "0123456789abcdef",
+			length:  16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, body := call(t, http.MethodGet, tt.target, "")
+			require.Equal(t, http.StatusOK, status, body)
+
+			value, ok := body["value"].(string)
+			require.True(t, ok)
+
+			if tt.length > 0 {
+				assert.Len(t, value, tt.length)
+			}
+
+			for _, c := range value {
+				assert.Contains(t, tt.charset, string(c))
+			}
+		})
+	}
+}
+
+// TestServeBatch verifies that a batch returns one value per request, in order.
+func TestServeBatch(t *testing.T) {
+	t.Parallel()
+
+	status, body := call(t, http.MethodPost, "/v1/batch", `{"requests": [
+		{"kind": "string", "size": 5, "charset": "numbers"},
+		{"kind": "passphrase", "size": 2},
+		{"kind": "token", "size": 4, "encoding": "hex"}
+	]}`)
+	require.Equal(t, http.StatusOK, status, body)
+
+	values, ok := body["values"].([]any)
+	require.True(t, ok)
+	require.Len(t, values, 3)
+	assert.Regexp(t, `^\d{5}$`, values[0])
+	assert.Regexp(t, `^[a-z]+-[a-z]+$`, values[1])
+	assert.Regexp(t, `^[0-9a-f]{8}$`, values[2])
+}
+
+// TestServeErrors verifies that failures are reported with the code of the
+// sentinel error that caused them.
+func TestServeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string // Description of the test case
+		method string // Request method
+		target string // Request URL
+		body   string // Request body
+		status int    // Expected status
+		code   string // Expected error code
+	}{
+		{name: "zero size", method: http.MethodGet, target: "/v1/string?size=0", status: http.StatusBadRequest, code: "invalid_size"},
+		{name: "zero size in batch", method: http.MethodPost, target: "/v1/batch", body: `{"requests": [{"kind": "token", "size": 0}]}`, status: http.StatusBadRequest, code: "invalid_size"},
+		{name: "empty chars", method: http.MethodGet, target: "/v1/string?chars=", status: http.StatusBadRequest, code: "empty_charset"},
+		{name: "unknown charset", method: http.MethodGet, target: "/v1/string?charset=hex", status: http.StatusBadRequest, code: "unknown_charset"},
+		{name: "unknown encoding", method: http.MethodGet, target: "/v1/token?encoding=base16", status: http.StatusBadRequest, code: "invalid_encoding"},
+		{name: "malformed size", method: http.MethodGet, target: "/v1/string?size=big", status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "charset and chars", method: http.MethodGet, target: "/v1/string?charset=all&chars=ab", status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "size over limit", method: http.MethodGet, target: "/v1/token?size=65", status: http.StatusBadRequest, code: "limit_exceeded"},
+		{name: "wrong method", method: http.MethodPost, target: "/v1/string", status: http.StatusMethodNotAllowed, code: "method_not_allowed"},
+		{name: "unknown path", method: http.MethodGet, target: "/v2/string", status: http.StatusNotFound, code: "not_found"},
+		{name: "malformed batch", method: http.MethodPost, target: "/v1/batch", body: `{"requests": [`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "unknown batch field", method: http.MethodPost, target: "/v1/batch", body: `{"items": []}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "unknown kind", method: http.MethodPost, target: "/v1/batch", body: `{"requests": [{"kind": "uuid"}]}`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "empty batch", method: http.MethodPost, target: "/v1/batch", body: `{"requests": []}`, status: http.StatusBadRequest, code: "invalid_size"},
+		{
+			name:   "batch over limit",
+			method: http.MethodPost,
+			target: "/v1/batch",
+			body:   `{"requests": [{"kind": "token"}, {"kind": "token"}, {"kind": "token"}, {"kind": "token"}]}`,
+			status: http.StatusBadRequest,
+			code:   "limit_exceeded",
+		},
+		{
+			name:   "body over limit",
+			method: http.MethodPost,
+			target: "/v1/batch",
+			body:   `{"requests": [{"kind": "string", "chars": "` + strings.Repeat("a", 600) + `"}]}`,
+			status: http.StatusRequestEntityTooLarge,
+			code:   "limit_exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, body := call(t, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.status, status)
+
+			errBody, ok := body["error"].(map[string]any)
+			require.True(t, ok, body)
+			assert.Equal(t, tt.code, errBody["code"])
+			assert.NotEmpty(t, errBody["message"])
+		})
+	}
+}
+
+// TestServeHealth verifies the health endpoint over a real connection.
+func TestServeHealth(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(newHandler(testLimits()))
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/healthz", nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ok", body["status"])
+}
+
+// TestServeCommand verifies flag validation and shutdown of the serve command.
+func TestServeCommand(t *testing.T) {
+	t.Parallel()
+
+	code, _, _ := execute(t, "serve", "-max-size", "0")
+	assert.Equal(t, exitUsage, code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, run(ctx, []string{"serve", "-addr", "127.0.0.1:0"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "listening on 127.0.0.1:")
+}
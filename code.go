@@ -0,0 +1,226 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Errors returned when formatting and parsing grouped codes.
+var (
+	ErrInvalidCodeFormat = errors.New("invalid code format")
+	ErrInvalidCode       = errors.New("invalid code: contains characters outside the charset")
+)
+
+// DefaultSeparator is the separator used between groups when
+// CodeFormat.Separator is empty.
+const DefaultSeparator = "-"
+
+// CaseFolding controls how the letters of a grouped code are cased.
+type CaseFolding int
+
+const (
+	// CasePreserve leaves letters as generated or typed.
+	CasePreserve CaseFolding = iota
+
+	// CaseUpper folds letters to uppercase.
+	CaseUpper
+
+	// CaseLower folds letters to lowercase.
+	CaseLower
+)
+
+// CodeFormat describes how codes such as license keys and recovery codes are
+// displayed, for example "XXXXX-XXXXX-XXXXX".
+//
+// A code has a canonical form, the characters without any separators, which is
+// what should be stored and compared. Format renders the canonical form for
+// display and Normalize recovers it from user input.
+type CodeFormat struct {
+	// GroupSize is the number of characters per group. Zero disables grouping.
+	GroupSize int
+
+	// Separator is inserted between groups. Defaults to DefaultSeparator.
+	Separator string
+
+	// Case folds the letters of generated, formatted and normalized codes.
+	Case CaseFolding
+}
+
+// Generate generates a cryptographically secure grouped code.
+//
+// Parameters:
+//   - size: the number of characters in the canonical code, excluding separators.
+//     Must be greater than 0.
+//   - charset: the string of characters from which the code will be generated.
+//     Cannot be empty and must not contain the separator. Characters are folded
+//     to the configured case before drawing, so "a" and "A" count once.
+//
+// Returns:
+//   - string: the formatted code.
+//   - error: an error if random generation fails or if invalid parameters are provided.
+func (f CodeFormat) Generate(size int, charset string) (string, error) {
+	return f.GenerateWithContext(context.Background(), size, charset)
+}
+
+// GenerateWithContext works like Generate but accepts a context for
+// cancellation support.
+func (f CodeFormat) GenerateWithContext(ctx context.Context, size int, charset string) (string, error) {
+	charset = f.foldCharset(charset)
+	if err := f.validate(charset); err != nil {
+		return "", err
+	}
+
+	code, err := StringWithContext(ctx, size, charset)
+	if err != nil {
+		return "", err
+	}
+
+	return f.Format(code), nil
+}
+
+// SeededGenerate generates a deterministic grouped code.
+//
+// Parameters:
+//   - size: the number of characters in the canonical code, excluding separators.
+//   - charset: the string of characters from which the code will be generated.
+//     Must not contain the separator. Characters are folded to the configured
+//     case before drawing, so "a" and "A" count once.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Generate() instead.
+func (f CodeFormat) SeededGenerate(size int, charset string, seed ...int64) (string, error) {
+	charset = f.foldCharset(charset)
+	if err := f.validate(charset); err != nil {
+		return "", err
+	}
+
+	return f.Format(SeededString(size, charset, seed...)), nil
+}
+
+// Format folds the case of a canonical code and splits it into groups.
+func (f CodeFormat) Format(code string) string {
+	code = f.fold(code)
+
+	if f.GroupSize <= 0 || len(code) <= f.GroupSize {
+		return code
+	}
+
+	separator := f.separator()
+
+	var b strings.Builder
+
+	b.Grow(len(code) + (len(code)/f.GroupSize)*len(separator))
+
+	for i := 0; i < len(code); i += f.GroupSize {
+		if i > 0 {
+			b.WriteString(separator)
+		}
+
+		b.WriteString(code[i:min(i+f.GroupSize, len(code))])
+	}
+
+	return b.String()
+}
+
+// Normalize recovers the canonical form of a user-typed code by removing
+// separators and whitespace and folding case.
+//
+// For example, with GroupSize 5, the default separator and CaseUpper, the inputs
+// "abcde-fghij", " ABCDE FGHIJ " and "abcdefghij" all normalize to "ABCDEFGHIJ".
+func (f CodeFormat) Normalize(input string) string {
+	separator := f.separator()
+
+	input = strings.ReplaceAll(input, separator, "")
+	input = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, input)
+
+	return f.fold(input)
+}
+
+// Parse normalizes input and verifies that every character of the result
+// belongs to charset, after case folding.
+//
+// Returns:
+//   - string: the canonical code, suitable for comparing against stored codes.
+//   - error: ErrInvalidCode if the code is empty or contains characters outside
+//     the charset.
+func (f CodeFormat) Parse(input, charset string) (string, error) {
+	code := f.Normalize(input)
+	if code == "" {
+		return "", fmt.Errorf("%w: code is empty", ErrInvalidCode)
+	}
+
+	allowed := f.fold(charset)
+	for i := range len(code) {
+		if strings.IndexByte(allowed, code[i]) < 0 {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCode, code[i])
+		}
+	}
+
+	return code, nil
+}
+
+// validate rejects formats that could not be parsed back losslessly.
+func (f CodeFormat) validate(charset string) error {
+	if f.GroupSize < 0 {
+		return fmt.Errorf("%w: group size cannot be negative", ErrInvalidCodeFormat)
+	}
+
+	if f.GroupSize > 0 && strings.Contains(charset, f.separator()) {
+		return fmt.Errorf("%w: charset contains separator %q", ErrInvalidCodeFormat, f.separator())
+	}
+
+	return nil
+}
+
+// separator returns the configured separator or the default.
+func (f CodeFormat) separator() string {
+	if f.Separator == "" {
+		return DefaultSeparator
+	}
+
+	return f.Separator
+}
+
+// fold applies the configured case folding to s.
+func (f CodeFormat) fold(s string) string {
+	switch f.Case {
+	case CaseUpper:
+		return strings.ToUpper(s)
+	case CaseLower:
+		return strings.ToLower(s)
+	default:
+		return s
+	}
+}
+
+// foldCharset folds the case of charset and drops the duplicates this creates,
+// so that every character of a generated code is equally likely after Format.
+func (f CodeFormat) foldCharset(charset string) string {
+	folded := f.fold(charset)
+	if folded == charset {
+		return charset
+	}
+
+	var seen [256]bool
+
+	unique := make([]byte, 0, len(folded))
+	for i := range len(folded) {
+		if !seen[folded[i]] {
+			seen[folded[i]] = true
+			unique = append(unique, folded[i])
+		}
+	}
+
+	return string(unique)
+}
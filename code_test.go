@@ -0,0 +1,150 @@
+package strand_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCodeFormatGenerate verifies that generated codes are grouped, cased and
+// parse back to their canonical form.
+func TestCodeFormatGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string            // Description of the test case
+		format  strand.CodeFormat // Format under test
+		size    int               // Canonical code size
+		charset string            // Character set to use
+		want    int               // Expected formatted length
+		groups  int               // Expected number of groups
+	}{
+		{
+			name:    "license key",
+			format:  strand.CodeFormat{GroupSize: 5},
+			size:    15,
+			charset: strand.UppercaseAlphabet + strand.Numbers,
+			want:    17,
+			groups:  3,
+		},
+		{
+			name:    "uneven final group",
+			format:  strand.CodeFormat{GroupSize: 4, Separator: " "},
+			size:    10,
+			charset: strand.Numbers,
+			want:    12,
+			groups:  3,
+		},
+		{
+			name:    "case folded to lower",
+			format:  strand.CodeFormat{GroupSize: 4, Case: strand.CaseLower},
+			size:    8,
+			charset: strand.Alphabet,
+			want:    9,
+			groups:  2,
+		},
+		{
+			name:    "no grouping",
+			format:  strand.CodeFormat{},
+			size:    12,
+			charset: strand.AlphaNumeric,
+			want:    12,
+			groups:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := tt.format.Generate(tt.size, tt.charset)
+			require.NoError(t, err)
+			assert.Len(t, code, tt.want)
+
+			separator := tt.format.Separator
+			if separator == "" {
+				separator = strand.DefaultSeparator
+			}
+
+			assert.Len(t, strings.Split(code, separator), tt.groups)
+
+			canonical, err := tt.format.Parse(code, tt.charset)
+			require.NoError(t, err)
+			assert.Len(t, canonical, tt.size)
+			assert.Equal(t, code, tt.format.Format(canonical))
+
+			seeded, err := tt.format.SeededGenerate(tt.size, tt.charset, 42)
+			require.NoError(t, err)
+			assert.Len(t, seeded, tt.want)
+
+			again, err := tt.format.SeededGenerate(tt.size, tt.charset, 42)
+			require.NoError(t, err)
+			assert.Equal(t, seeded, again, "Same seed should produce same output")
+		})
+	}
+}
+
+// TestCodeFormatNormalize verifies that user-typed input is reduced to the
+// canonical form.
+func TestCodeFormatNormalize(t *testing.T) {
+	t.Parallel()
+
+	format := strand.CodeFormat{GroupSize: 5, Case: strand.CaseUpper}
+
+	for _, input := range []string{
+		"ABCDE-FGHIJ",
+		"abcde-fghij",
+		" ABCDE FGHIJ ",
+		"abcdefghij",
+		"ab-cde\tfg-hij\n",
+	} {
+		assert.Equal(t, "ABCDEFGHIJ", format.Normalize(input), "input %q", input)
+	}
+
+	preserve := strand.CodeFormat{GroupSize: 3, Separator: "."}
+	assert.Equal(t, "aBc1-2", preserve.Normalize("aBc.1-2"))
+}
+
+// TestCodeFormatParse verifies that codes outside the charset are rejected.
+func TestCodeFormatParse(t *testing.T) {
+	t.Parallel()
+
+	format := strand.CodeFormat{GroupSize: 4, Case: strand.CaseUpper}
+
+	code, err := format.Parse("abcd-1234", strand.UppercaseAlphabet+strand.Numbers)
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD1234", code)
+
+	_, err = format.Parse("ABCD-12!4", strand.UppercaseAlphabet+strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidCode)
+
+	_, err = format.Parse(" - ", strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidCode)
+}
+
+// TestCodeFormatErrors verifies that ambiguous formats and invalid inputs are rejected.
+func TestCodeFormatErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.CodeFormat{GroupSize: -1}.Generate(10, strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidCodeFormat)
+
+	_, err = strand.CodeFormat{GroupSize: 4}.Generate(10, strand.Symbols)
+	require.ErrorIs(t, err, strand.ErrInvalidCodeFormat)
+
+	_, err = strand.CodeFormat{GroupSize: 4}.SeededGenerate(10, strand.ALL, 42)
+	require.ErrorIs(t, err, strand.ErrInvalidCodeFormat)
+
+	_, err = strand.CodeFormat{GroupSize: 4}.Generate(0, strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.CodeFormat{GroupSize: 4}.GenerateWithContext(ctx, 8, strand.Numbers)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,101 @@
+package strand
+
+import (
+	"context"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// ErrInvalidDerivationKey is returned when a master key is too short to derive
+// secrets from.
+var ErrInvalidDerivationKey = errors.New("invalid derivation key: too short")
+
+// MinDerivationKeySize is the minimum length in bytes of a master key passed to
+// Derive and DeriveBytes.
+const MinDerivationKeySize = 32
+
+// deriveDomain is mixed into every derivation so that derived secrets differ
+// from other HKDF uses of the same master key.
+const deriveDomain = "strand-derive-v1"
+
+// Derive deterministically derives a secret string from a master key, such as a
+// per-tenant webhook secret.
+//
+// The master key, info, size and charset are combined with HKDF-SHA256 into a
+// key for a ChaCha8 stream, and characters are drawn from the stream with the
+// same unbiased rejection sampling as String. The result is as unpredictable as
+// String to anyone without the master key, yet the same inputs always produce
+// the same output, on every machine and in every release.
+//
+// Changing any input, including size or charset, yields an unrelated secret, so
+// a shorter secret is not a prefix of a longer one.
+//
+// Parameters:
+//   - masterKey: high-entropy key material of at least MinDerivationKeySize
+//     bytes, such as bytes from SecretKey. Passwords are not suitable.
+//   - info: a label that distinguishes derived secrets, such as
+//     "webhook-secret/tenant-42".
+//   - size: the length of the string. Must be greater than 0.
+//   - charset: the string of characters from which the string will be generated.
+//     Cannot be empty.
+//
+// Returns:
+//   - string: the derived secret.
+//   - error: ErrInvalidDerivationKey if the master key is too short, or an error
+//     if invalid parameters are provided.
+func Derive(masterKey []byte, info string, size int, charset string) (string, error) {
+	return DeriveWithContext(context.Background(), masterKey, info, size, charset)
+}
+
+// DeriveWithContext works like Derive but accepts a context for cancellation support.
+func DeriveWithContext(ctx context.Context, masterKey []byte, info string, size int, charset string) (string, error) {
+	g, err := derivedGenerator(masterKey, "string", info, size, charset)
+	if err != nil {
+		return "", err
+	}
+
+	return g.StringWithContext(ctx, size, charset)
+}
+
+// DeriveBytes deterministically derives size raw bytes from a master key, for
+// use as a key. It works like Derive, and the bytes are unrelated to any string
+// derived with the same info.
+//
+// Returns ErrInvalidDerivationKey if the master key is too short, or
+// ErrInvalidSize if size is invalid.
+func DeriveBytes(masterKey []byte, info string, size int) ([]byte, error) {
+	g, err := derivedGenerator(masterKey, "bytes", info, size, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return g.read(context.Background(), size)
+}
+
+// derivedGenerator returns a Generator reading a ChaCha8 stream keyed with the
+// HKDF of the master key and every input that shapes the output.
+func derivedGenerator(masterKey []byte, mode, info string, size int, charset string) (*Generator, error) {
+	if len(masterKey) < MinDerivationKeySize {
+		return nil, fmt.Errorf("%w: must be at least %d bytes", ErrInvalidDerivationKey, MinDerivationKeySize)
+	}
+
+	// Each field is length-prefixed so that no two inputs share an encoding.
+	label := make([]byte, 0, len(deriveDomain)+len(mode)+len(info)+len(charset)+40)
+	for _, field := range []string{deriveDomain, mode, info, charset} {
+		label = binary.BigEndian.AppendUint64(label, uint64(len(field)))
+		label = append(label, field...)
+	}
+
+	label = binary.BigEndian.AppendUint64(label, uint64(max(size, 0)))
+
+	key, err := hkdf.Key(sha256.New, masterKey, nil, string(label), 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	return NewGenerator(rand.NewChaCha8([32]byte(key))), nil //nolint:gosec // ChaCha8 is cryptographically strong.
+}
@@ -0,0 +1,92 @@
+package strand_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// masterKey returns a valid master key filled with fill.
+func masterKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, strand.MinDerivationKeySize)
+}
+
+// TestDeriveGolden pins derived output, which must never change between releases.
+func TestDeriveGolden(t *testing.T) {
+	t.Parallel()
+
+	secret, err := strand.Derive(masterKey(0x42), "webhook-secret/tenant-42", 24, strand.AlphaNumeric)
+	require.NoError(t, err)
+	assert.Equal(t, "EBQi6XzxPLBZIgrNbvRbAtQv", secret)
+
+	key, err := strand.DeriveBytes(masterKey(0x42), "signing-key", 8)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x6c, 0x10, 0x28, 0x54, 0x60, 0x39, 0xa9, 0x38}, key)
+}
+
+// TestDerive verifies that every input changes the derived secret.
+func TestDerive(t *testing.T) {
+	t.Parallel()
+
+	base, err := strand.Derive(masterKey(1), "tenant-1", 32, strand.ALL)
+	require.NoError(t, err)
+	assert.Len(t, base, 32)
+	assert.True(t, onlyContains(base, strand.ALL))
+
+	again, err := strand.Derive(masterKey(1), "tenant-1", 32, strand.ALL)
+	require.NoError(t, err)
+	assert.Equal(t, base, again, "Same inputs should produce same output")
+
+	tests := []struct {
+		name    string // Description of the test case
+		key     []byte // Master key
+		info    string // Info label
+		size    int    // Length of the secret
+		charset string // Charset of the secret
+	}{
+		{name: "other key", key: masterKey(2), info: "tenant-1", size: 32, charset: strand.ALL},
+		{name: "other info", key: masterKey(1), info: "tenant-2", size: 32, charset: strand.ALL},
+		{name: "other size", key: masterKey(1), info: "tenant-1", size: 33, charset: strand.ALL},
+		{name: "other charset", key: masterKey(1), info: "tenant-1", size: 32, charset: strand.AlphaNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			other, err := strand.Derive(tt.key, tt.info, tt.size, tt.charset)
+			require.NoError(t, err)
+			assert.NotEqual(t, base[:16], other[:16])
+		})
+	}
+}
+
+// TestDeriveErrors verifies that weak keys and invalid parameters are rejected.
+func TestDeriveErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.Derive(masterKey(1)[:31], "info", 16, strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidDerivationKey)
+
+	_, err = strand.DeriveBytes(nil, "info", 16)
+	require.ErrorIs(t, err, strand.ErrInvalidDerivationKey)
+
+	_, err = strand.Derive(masterKey(1), "info", 0, strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.Derive(masterKey(1), "info", 16, "")
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.DeriveBytes(masterKey(1), "info", -1)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.DeriveWithContext(ctx, masterKey(1), "info", 16, strand.Numbers)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,150 @@
+package strand
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by IssueToken and VerifyToken.
+var (
+	ErrInvalidDigest = errors.New("invalid token digest")
+	ErrTokenMismatch = errors.New("token does not match digest")
+	ErrEmptyPepper   = errors.New("pepper must not be empty")
+)
+
+// Algorithm identifiers that prefix stored digests.
+const (
+	// DigestSHA256 identifies a plain SHA-256 digest of the token.
+	DigestSHA256 = "sha256"
+
+	// DigestHMACSHA256 identifies an HMAC-SHA256 of the token keyed with a
+	// server-side pepper.
+	DigestHMACSHA256 = "hmac-sha256"
+)
+
+// digestSeparator separates the algorithm identifier from the hex digest.
+const digestSeparator = "$"
+
+// IssueToken generates a token of the shape described by spec and returns the
+// plaintext together with a digest that is safe to store.
+//
+// The plaintext should be shown to its owner once and never stored. The digest
+// has the form "sha256$<hex>", or "hmac-sha256$<hex>" when a pepper is given,
+// so that VerifyToken knows how it was computed and older digests keep
+// verifying if the algorithm changes.
+//
+// Parameters:
+//   - spec: the length and charset of the token. Tokens are looked up by their
+//     digest, so they need enough entropy that guessing one is infeasible, such
+//     as 32 characters of AlphaNumeric.
+//   - pepper: server-side secret, or nil for none. If given, the digest is an
+//     HMAC keyed with the pepper, so a leaked database alone does not allow
+//     offline guessing.
+//
+// Returns:
+//   - string: the plaintext token.
+//   - string: the digest to store.
+//   - error: an error if random generation fails, if spec is invalid, or
+//     ErrEmptyPepper if the pepper is empty but not nil.
+func IssueToken(spec Spec, pepper []byte) (string, string, error) {
+	return IssueTokenWithContext(context.Background(), spec, pepper)
+}
+
+// IssueTokenWithContext works like IssueToken but accepts a context for
+// cancellation support.
+func IssueTokenWithContext(ctx context.Context, spec Spec, pepper []byte) (string, string, error) {
+	if pepper != nil && len(pepper) == 0 {
+		return "", "", ErrEmptyPepper
+	}
+
+	token, err := spec.GenerateWithContext(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	if pepper == nil {
+		return token, DigestSHA256 + digestSeparator + hex.EncodeToString(digestSHA256(token)), nil
+	}
+
+	return token, DigestHMACSHA256 + digestSeparator + hex.EncodeToString(digestHMACSHA256(token, pepper)), nil
+}
+
+// VerifyToken reports whether plaintext is the token a digest from IssueToken
+// was computed for. Digests are compared in constant time.
+//
+// Parameters:
+//   - plaintext: the token presented by the client.
+//   - digest: the stored digest.
+//   - peppers: the server-side secrets, or nil for none. An HMAC digest matches
+//     if it was computed with any of them, so a new pepper can be introduced
+//     while digests issued with the old one are still in use. Ignored for
+//     SHA-256 digests. None may be empty.
+//
+// Returns:
+//   - error: nil if the token matches, ErrTokenMismatch if it does not,
+//     ErrEmptyPepper if a pepper is empty, or ErrInvalidDigest if the digest is
+//     malformed, uses an unknown algorithm, or requires a pepper and none was
+//     given.
+func VerifyToken(plaintext, digest string, peppers [][]byte) error {
+	for _, pepper := range peppers {
+		if len(pepper) == 0 {
+			return ErrEmptyPepper
+		}
+	}
+
+	algorithm, encoded, ok := strings.Cut(digest, digestSeparator)
+	if !ok {
+		return fmt.Errorf("%w: missing algorithm", ErrInvalidDigest)
+	}
+
+	want, err := hex.DecodeString(encoded)
+	if err != nil || len(want) != sha256.Size {
+		return fmt.Errorf("%w: malformed %s digest", ErrInvalidDigest, algorithm)
+	}
+
+	switch algorithm {
+	case DigestSHA256:
+		if subtle.ConstantTimeCompare(digestSHA256(plaintext), want) == 1 {
+			return nil
+		}
+	case DigestHMACSHA256:
+		if len(peppers) == 0 {
+			return fmt.Errorf("%w: %s digest requires a pepper", ErrInvalidDigest, algorithm)
+		}
+
+		// Every pepper is tried, so the time taken does not reveal which one matched.
+		match := 0
+		for _, pepper := range peppers {
+			match |= subtle.ConstantTimeCompare(digestHMACSHA256(plaintext, pepper), want)
+		}
+
+		if match == 1 {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidDigest, algorithm)
+	}
+
+	return ErrTokenMismatch
+}
+
+// digestSHA256 returns the SHA-256 digest of token.
+func digestSHA256(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+
+	return sum[:]
+}
+
+// digestHMACSHA256 returns the HMAC-SHA256 of token keyed with pepper.
+func digestHMACSHA256(token string, pepper []byte) []byte {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write([]byte(token))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,131 @@
+package strand_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIssueToken verifies that issued tokens verify against their digest and
+// that other tokens do not.
+func TestIssueToken(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 32, Charset: strand.AlphaNumeric}
+	pepper := []byte("server-side pepper")
+
+	tests := []struct {
+		name      string // Description of the test case
+		pepper    []byte // Pepper used to issue the token
+		algorithm string // Expected digest algorithm
+	}{
+		{name: "unpeppered", pepper: nil, algorithm: strand.DigestSHA256},
+		{name: "peppered", pepper: pepper, algorithm: strand.DigestHMACSHA256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, digest, err := strand.IssueToken(spec, tt.pepper)
+			require.NoError(t, err)
+			assert.Len(t, token, 32)
+			assert.True(t, strings.HasPrefix(digest, tt.algorithm+"$"))
+			assert.NotContains(t, digest, token)
+
+			peppers := [][]byte{tt.pepper}
+			if tt.pepper == nil {
+				peppers = nil
+			}
+
+			require.NoError(t, strand.VerifyToken(token, digest, peppers))
+			require.ErrorIs(t, strand.VerifyToken(token+"x", digest, peppers), strand.ErrTokenMismatch)
+			require.ErrorIs(t, strand.VerifyToken(strings.ToUpper(token), digest, peppers), strand.ErrTokenMismatch)
+		})
+	}
+}
+
+// TestVerifyTokenVector verifies the digest format against a known SHA-256 value.
+func TestVerifyTokenVector(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256$ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	require.NoError(t, strand.VerifyToken("abc", digest, nil))
+	require.ErrorIs(t, strand.VerifyToken("abd", digest, nil), strand.ErrTokenMismatch)
+}
+
+// TestVerifyTokenPeppers verifies pepper rotation and wrong peppers.
+func TestVerifyTokenPeppers(t *testing.T) {
+	t.Parallel()
+
+	oldPepper, newPepper := []byte("old pepper"), []byte("new pepper")
+
+	token, digest, err := strand.IssueToken(strand.Spec{Size: 24, Charset: strand.ALL}, oldPepper)
+	require.NoError(t, err)
+
+	require.NoError(t, strand.VerifyToken(token, digest, [][]byte{newPepper, oldPepper}))
+	require.ErrorIs(t, strand.VerifyToken(token, digest, [][]byte{newPepper}), strand.ErrTokenMismatch)
+	require.ErrorIs(t, strand.VerifyToken(token, digest, nil), strand.ErrInvalidDigest)
+}
+
+// TestVerifyTokenErrors verifies that malformed digests are rejected.
+func TestVerifyTokenErrors(t *testing.T) {
+	t.Parallel()
+
+	valid := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name   string // Description of the test case
+		digest string // Malformed digest
+	}{
+		{name: "empty", digest: ""},
+		{name: "missing algorithm", digest: valid},
+		{name: "unknown algorithm", digest: "md5$" + valid},
+		{name: "not hex", digest: "sha256$" + strings.Repeat("zz", 32)},
+		{name: "truncated", digest: "sha256$" + valid[:62]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorIs(t, strand.VerifyToken("token", tt.digest, [][]byte{[]byte("pepper")}), strand.ErrInvalidDigest)
+		})
+	}
+}
+
+// TestIssueTokenErrors verifies that invalid specs and canceled contexts are reported.
+func TestIssueTokenErrors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := strand.IssueToken(strand.Spec{Size: 0, Charset: strand.AlphaNumeric}, nil)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, _, err = strand.IssueToken(strand.Spec{Size: 16}, nil)
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = strand.IssueTokenWithContext(ctx, strand.Spec{Size: 16, Charset: strand.AlphaNumeric}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, _, err = strand.IssueToken(strand.Spec{Size: 16, Charset: strand.AlphaNumeric}, []byte{})
+	require.ErrorIs(t, err, strand.ErrEmptyPepper)
+}
+
+// TestVerifyTokenEmptyPepper verifies that an empty pepper is rejected instead
+// of keying the HMAC with nothing.
+func TestVerifyTokenEmptyPepper(t *testing.T) {
+	t.Parallel()
+
+	token, digest, err := strand.IssueToken(strand.Spec{Size: 32, Charset: strand.AlphaNumeric}, []byte("pepper"))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, strand.VerifyToken(token, digest, [][]byte{[]byte("pepper"), nil}), strand.ErrEmptyPepper)
+	require.ErrorIs(t, strand.VerifyToken(token, digest, [][]byte{{}}), strand.ErrEmptyPepper)
+}
@@ -0,0 +1,202 @@
+package strand
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidEncoding is returned when a token is requested with an unknown Encoding.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+const (
+	// Base58Alphabet is the Bitcoin base58 alphabet, which omits 0, O, I and l
+	// to avoid visually ambiguous characters.
+	Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// Base62Alphabet contains digits followed by uppercase and lowercase letters,
+	// so that encoded values sort in the same order as ASCII.
+	Base62Alphabet = Numbers + UppercaseAlphabet + LowercaseAlphabet
+)
+
+// The codecs behind Base58 and Base62, built once since BaseN is immutable.
+var (
+	base58Codec = MustBaseN(Base58Alphabet) //nolint:gochecknoglobals // Immutable and safe for concurrent use.
+	base62Codec = MustBaseN(Base62Alphabet) //nolint:gochecknoglobals // Immutable and safe for concurrent use.
+)
+
+// Encoding selects how the raw random bytes of a token are rendered as text.
+type Encoding int
+
+const (
+	// Hex encodes bytes as lowercase hexadecimal, two characters per byte.
+	Hex Encoding = iota
+
+	// Base32 encodes bytes with the RFC 4648 standard alphabet and padding.
+	Base32
+
+	// Base32NoPadding encodes bytes with the RFC 4648 standard alphabet without padding.
+	Base32NoPadding
+
+	// Base64URL encodes bytes with the RFC 4648 URL and filename safe alphabet
+	// without padding, so tokens can be used in URLs unescaped.
+	Base64URL
+
+	// Base58 encodes bytes with Base58Alphabet.
+	Base58
+
+	// Base62 encodes bytes with Base62Alphabet.
+	Base62
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case Hex:
+		return "hex"
+	case Base32:
+		return "base32"
+	case Base32NoPadding:
+		return "base32-nopad"
+	case Base64URL:
+		return "base64url"
+	case Base58:
+		return "base58"
+	case Base62:
+		return "base62"
+	default:
+		return "Encoding(" + strconv.Itoa(int(e)) + ")"
+	}
+}
+
+// ParseEncoding returns the Encoding whose String method returns name.
+//
+// Returns ErrInvalidEncoding if no built-in encoding has that name.
+func ParseEncoding(name string) (Encoding, error) {
+	for e := Hex; e.valid(); e++ {
+		if e.String() == name {
+			return e, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidEncoding, name)
+}
+
+// EncodeToString returns the encoding of src.
+//
+// Returns an empty string if the encoding is unknown.
+func (e Encoding) EncodeToString(src []byte) string {
+	switch e {
+	case Hex:
+		return hex.EncodeToString(src)
+	case Base32:
+		return base32.StdEncoding.EncodeToString(src)
+	case Base32NoPadding:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(src)
+	case Base64URL:
+		return base64.RawURLEncoding.EncodeToString(src)
+	case Base58:
+		return base58Codec.Encode(src)
+	case Base62:
+		return base62Codec.Encode(src)
+	default:
+		return ""
+	}
+}
+
+// DecodeString returns the bytes represented by the encoded string s.
+//
+// Returns:
+//   - []byte: the decoded bytes.
+//   - error: ErrInvalidEncoding if the encoding is unknown, or an error if s is
+//     not valid for the encoding.
+func (e Encoding) DecodeString(s string) ([]byte, error) {
+	var (
+		decoded []byte
+		err     error
+	)
+
+	switch e {
+	case Hex:
+		decoded, err = hex.DecodeString(s)
+	case Base32:
+		decoded, err = base32.StdEncoding.DecodeString(s)
+	case Base32NoPadding:
+		decoded, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	case Base64URL:
+		decoded, err = base64.RawURLEncoding.DecodeString(s)
+	case Base58:
+		return base58Codec.Decode(s)
+	case Base62:
+		return base62Codec.Decode(s)
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, e)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %v: %w", e, err)
+	}
+
+	return decoded, nil
+}
+
+// valid reports whether e is one of the built-in encodings.
+func (e Encoding) valid() bool {
+	return e >= Hex && e <= Base62
+}
+
+// Token generates size bytes of cryptographically secure randomness and returns
+// them encoded with enc.
+//
+// Unlike String, which draws characters from a charset, Token is sized by raw
+// entropy: Token(32, Base64URL) always carries 256 bits regardless of the
+// encoding's alphabet.
+//
+// Parameters:
+//   - size: the number of random bytes. Must be greater than 0.
+//   - enc: the encoding used to render the bytes.
+//
+// Returns:
+//   - string: the encoded token.
+//   - error: an error if random generation fails or if invalid parameters are provided.
+func Token(size int, enc Encoding) (string, error) {
+	return TokenWithContext(context.Background(), size, enc)
+}
+
+// TokenWithContext works like Token but accepts a context for cancellation support.
+func TokenWithContext(ctx context.Context, size int, enc Encoding) (string, error) {
+	token, _, err := TokenBytesWithContext(ctx, size, enc)
+
+	return token, err
+}
+
+// TokenBytes works like Token but also returns the raw random bytes, for
+// callers that store or hash the underlying value.
+//
+// Returns:
+//   - string: the encoded token.
+//   - []byte: the raw random bytes the token encodes.
+//   - error: an error if random generation fails or if invalid parameters are provided.
+func TokenBytes(size int, enc Encoding) (string, []byte, error) {
+	return TokenBytesWithContext(context.Background(), size, enc)
+}
+
+// TokenBytesWithContext works like TokenBytes but accepts a context for
+// cancellation support.
+func TokenBytesWithContext(ctx context.Context, size int, enc Encoding) (string, []byte, error) {
+	return defaultGenerator().TokenBytesWithContext(ctx, size, enc)
+}
+
+// MustToken works like Token but panics on error instead of returning it.
+func MustToken(size int, enc Encoding) string {
+	token, err := Token(size, enc)
+	if err != nil {
+		panic(err)
+	}
+
+	return token
+}
@@ -0,0 +1,114 @@
+package strand_test
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncodingVectors verifies each encoding against known outputs.
+func TestEncodingVectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		enc   strand.Encoding // Encoding under test
+		input []byte          // Raw bytes to encode
+		want  string          // Expected encoding
+	}{
+		{strand.Hex, []byte("Hello World!"), "48656c6c6f20576f726c6421"},
+		{strand.Base32, []byte("foo"), "MZXW6==="},
+		{strand.Base32NoPadding, []byte("foo"), "MZXW6"},
+		{strand.Base64URL, []byte{0xfb, 0xff, 0xbf}, "-_-_"},
+		{strand.Base58, []byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{strand.Base58, []byte{0x00, 0x00, 0x28, 0x7f, 0xb4, 0xcd}, "11233QC4"},
+		{strand.Base58, []byte{}, ""},
+		{strand.Base62, []byte{0xff}, "47"},
+		{strand.Base62, []byte{0x00, 0x01, 0x00}, "048"},
+		{strand.Base62, []byte("Hello World!"), "T8dgcjRGkZ3aysdN"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.enc.EncodeToString(tt.input), "%s of %x", tt.enc, tt.input)
+	}
+
+	assert.Empty(t, strand.Encoding(99).EncodeToString([]byte("x")))
+	assert.Equal(t, "Encoding(99)", strand.Encoding(99).String())
+}
+
+// TestToken verifies that tokens carry the requested amount of raw entropy and
+// decode back to the returned bytes.
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	decoders := map[strand.Encoding]func(string) ([]byte, error){
+		strand.Hex:             hex.DecodeString,
+		strand.Base32:          base32.StdEncoding.DecodeString,
+		strand.Base32NoPadding: base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString,
+		strand.Base64URL:       base64.RawURLEncoding.DecodeString,
+	}
+
+	for _, enc := range []strand.Encoding{
+		strand.Hex, strand.Base32, strand.Base32NoPadding, strand.Base64URL, strand.Base58, strand.Base62,
+	} {
+		t.Run(enc.String(), func(t *testing.T) {
+			t.Parallel()
+
+			token, raw, err := strand.TokenBytes(32, enc)
+			require.NoError(t, err)
+			assert.Len(t, raw, 32)
+			assert.Equal(t, enc.EncodeToString(raw), token)
+
+			if decode, ok := decoders[enc]; ok {
+				decoded, err := decode(token)
+				require.NoError(t, err)
+				assert.Equal(t, raw, decoded)
+			}
+
+			other, err := strand.Token(32, enc)
+			require.NoError(t, err)
+			assert.NotEqual(t, token, other)
+		})
+	}
+}
+
+// TestTokenErrors verifies that invalid parameters and cancellation are reported.
+func TestTokenErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.Token(0, strand.Hex)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.Token(16, strand.Encoding(-1))
+	require.ErrorIs(t, err, strand.ErrInvalidEncoding)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.TokenWithContext(ctx, 16, strand.Hex)
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Len(t, strand.MustToken(16, strand.Hex), 32)
+	assert.PanicsWithError(t, strand.ErrInvalidSize.Error(), func() {
+		strand.MustToken(0, strand.Hex)
+	})
+}
+
+// TestParseEncoding verifies that every encoding round-trips through its name.
+func TestParseEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, enc := range []strand.Encoding{strand.Hex, strand.Base32, strand.Base32NoPadding, strand.Base64URL, strand.Base58, strand.Base62} {
+		got, err := strand.ParseEncoding(enc.String())
+		require.NoError(t, err)
+		assert.Equal(t, enc, got)
+	}
+
+	_, err := strand.ParseEncoding("base16")
+	require.ErrorIs(t, err, strand.ErrInvalidEncoding)
+}
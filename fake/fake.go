@@ -0,0 +1,344 @@
+// Package fake produces plausible, reproducible test fixtures such as names,
+// email addresses, domains, postal addresses, phone numbers, dates and UUIDs.
+//
+// Every value is derived from a single seed using strand's seeded generation, so
+// a Faker created with the same seed and locale yields the same sequence of
+// values on every run and every machine.
+//
+// Security Notice: the output is predictable by design and must never be used
+// for passwords, tokens or other secrets.
+package fake
+
+import (
+	"embed"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+)
+
+// ErrUnknownLocale is returned when no embedded data exists for a locale.
+var ErrUnknownLocale = errors.New("unknown locale")
+
+// DefaultLocale is the locale used by New.
+const DefaultLocale = "en_US"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// locale holds the embedded data for a single locale.
+type locale struct {
+	Country              string   `json:"country"`
+	FirstNames           []string `json:"firstNames"`
+	LastNames            []string `json:"lastNames"`
+	Streets              []string `json:"streets"`
+	StreetSuffixes       []string `json:"streetSuffixes"`
+	Cities               []city   `json:"cities"`
+	StreetNumberPatterns []string `json:"streetNumberPatterns"`
+	PostcodePattern      string   `json:"postcodePattern"`
+	PhonePatterns        []string `json:"phonePatterns"`
+	AddressFormat        string   `json:"addressFormat"`
+	StreetFormat         string   `json:"streetFormat"`
+	DomainWords          []string `json:"domainWords"`
+	TLDs                 []string `json:"tlds"`
+}
+
+// city is a city together with the region it belongs to.
+type city struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+// Address is a postal address.
+type Address struct {
+	Street     string
+	Number     string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+
+	format string
+}
+
+// String formats the address on a single line following the conventions of the
+// locale it was generated for.
+func (a Address) String() string {
+	return strings.NewReplacer(
+		"{street}", a.Street,
+		"{number}", a.Number,
+		"{city}", a.City,
+		"{region}", a.Region,
+		"{postcode}", a.PostalCode,
+	).Replace(a.format)
+}
+
+// Faker generates fixture data from a deterministic stream.
+//
+// A Faker is not safe for concurrent use; create one per goroutine, each with
+// its own seed.
+type Faker struct {
+	gen    *strand.SeededGenerator
+	locale *locale
+
+	streetNumbers []*strand.Pattern
+	postcode      *strand.Pattern
+	phones        []*strand.Pattern
+}
+
+// New creates a Faker for DefaultLocale.
+//
+// Parameters:
+//   - seed: the value from which every generated fixture is derived.
+func New(seed int64) *Faker {
+	f, err := NewWithLocale(seed, DefaultLocale)
+	if err != nil {
+		// The default locale is embedded, so this only happens if the
+		// package itself is broken.
+		panic(err)
+	}
+
+	return f
+}
+
+// NewWithLocale creates a Faker that draws names, addresses and phone numbers
+// from the embedded data for the given locale.
+//
+// Parameters:
+//   - seed: the value from which every generated fixture is derived.
+//   - name: the locale, such as "en_US" or "de_DE". See Locales.
+//
+// Returns:
+//   - *Faker: the faker.
+//   - error: ErrUnknownLocale if no data is embedded for the locale.
+func NewWithLocale(seed int64, name string) (*Faker, error) {
+	data, err := localeFS.ReadFile(path.Join("locales", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownLocale, name)
+	}
+
+	var l locale
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse locale %q: %w", name, err)
+	}
+
+	f := &Faker{gen: strand.NewSeededGenerator(seed), locale: &l}
+
+	if f.streetNumbers, err = compilePatterns(name, l.StreetNumberPatterns); err != nil {
+		return nil, err
+	}
+
+	if f.phones, err = compilePatterns(name, l.PhonePatterns); err != nil {
+		return nil, err
+	}
+
+	postcode, err := compilePatterns(name, []string{l.PostcodePattern})
+	if err != nil {
+		return nil, err
+	}
+
+	f.postcode = postcode[0]
+
+	return f, nil
+}
+
+// compilePatterns compiles the masks of a locale, in which N stands for a
+// non-zero digit.
+func compilePatterns(name string, masks []string) ([]*strand.Pattern, error) {
+	patterns := make([]*strand.Pattern, len(masks))
+
+	for i, mask := range masks {
+		p, err := strand.CompilePattern(mask, map[rune]string{'N': "123456789"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile pattern %q of locale %q: %w", mask, name, err)
+		}
+
+		patterns[i] = p
+	}
+
+	return patterns, nil
+}
+
+// Locales returns the names of the embedded locales in sorted order.
+func Locales() []string {
+	entries, _ := localeFS.ReadDir("locales")
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// FirstName returns a given name.
+func (f *Faker) FirstName() string {
+	return pick(f, f.locale.FirstNames)
+}
+
+// LastName returns a family name.
+func (f *Faker) LastName() string {
+	return pick(f, f.locale.LastNames)
+}
+
+// Name returns a full name.
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a lowercase ASCII username built from a name, such as
+// "mary.smith", "jsmith" or "mary_smith42".
+func (f *Faker) Username() string {
+	first, last := ascii(f.FirstName()), ascii(f.LastName())
+
+	switch f.intN(4) {
+	case 0:
+		return first + "." + last
+	case 1:
+		return first[:1] + last
+	case 2:
+		return first + "_" + last + f.digits(2)
+	default:
+		return first + last + f.digits(4)
+	}
+}
+
+// Email returns an email address at one of the domains reserved for
+// documentation by RFC 2606, so fixtures can never reach a real mailbox.
+func (f *Faker) Email() string {
+	return f.Username() + "@" + pick(f, []string{"example.com", "example.net", "example.org"})
+}
+
+// Domain returns a plausible registrable domain name, such as "bluefalcon.com".
+func (f *Faker) Domain() string {
+	first := ascii(pick(f, f.locale.DomainWords))
+
+	second := ascii(pick(f, f.locale.DomainWords))
+	for second == first {
+		second = ascii(pick(f, f.locale.DomainWords))
+	}
+
+	separator := ""
+	if f.intN(3) == 0 {
+		separator = "-"
+	}
+
+	return first + separator + second + "." + pick(f, f.locale.TLDs)
+}
+
+// Hostname returns a fully qualified host name within a generated domain, such
+// as "api-3.bluefalcon.com".
+func (f *Faker) Hostname() string {
+	role := pick(f, []string{"api", "app", "cache", "db", "mail", "web", "worker"})
+
+	return fmt.Sprintf("%s-%d.%s", role, f.intN(20)+1, f.Domain())
+}
+
+// Address returns a postal address in the locale's format.
+func (f *Faker) Address() Address {
+	c := pick(f, f.locale.Cities)
+	street := strings.NewReplacer(
+		"{name}", pick(f, f.locale.Streets),
+		"{suffix}", pick(f, f.locale.StreetSuffixes),
+	).Replace(f.locale.StreetFormat)
+
+	return Address{
+		Street:     street,
+		Number:     f.pattern(pick(f, f.streetNumbers)),
+		City:       c.Name,
+		Region:     c.Region,
+		PostalCode: f.pattern(f.postcode),
+		Country:    f.locale.Country,
+		format:     f.locale.AddressFormat,
+	}
+}
+
+// Phone returns a phone number in one of the locale's formats.
+func (f *Faker) Phone() string {
+	return f.pattern(pick(f, f.phones))
+}
+
+// Date returns a time uniformly distributed in [from, to), truncated to the
+// second. It returns from if to is not after from.
+func (f *Faker) Date(from, to time.Time) time.Time {
+	span := to.Unix() - from.Unix()
+	if span <= 0 {
+		return from
+	}
+
+	// A SeededGenerator never fails, so the error is always nil.
+	offset, _ := strand.N(f.gen, span)
+
+	return time.Unix(from.Unix()+offset, 0).In(from.Location())
+}
+
+// UUID returns a random (version 4) UUID in its canonical textual form.
+func (f *Faker) UUID() string {
+	var b [16]byte
+
+	for i := 0; i < len(b); i += 8 {
+		v, _ := f.gen.Uint64()
+		binary.LittleEndian.PutUint64(b[i:], v)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4.
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant.
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// digits returns n random decimal digits.
+func (f *Faker) digits(n int) string {
+	return f.gen.String(n, strand.Numbers)
+}
+
+// pattern expands one of the locale's compiled patterns.
+func (f *Faker) pattern(p *strand.Pattern) string {
+	seed, _ := f.gen.Uint64()
+
+	return p.SeededGenerate(int64(seed)) //nolint:gosec // Wrapping is fine, the seed only has to be reproducible.
+}
+
+// intN returns a uniform integer in [0, n). A SeededGenerator never fails, so
+// the error is always nil.
+func (f *Faker) intN(n int) int {
+	v, _ := f.gen.IntN(n)
+
+	return v
+}
+
+// pick returns a random element of items.
+func pick[T any](f *Faker, items []T) T {
+	return items[f.intN(len(items))]
+}
+
+// ascii lowercases s and transliterates or drops everything that is not a
+// letter or digit, so names can be used in usernames and domains.
+func ascii(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == 'ä':
+			b.WriteString("ae")
+		case r == 'ö':
+			b.WriteString("oe")
+		case r == 'ü':
+			b.WriteString("ue")
+		case r == 'ß':
+			b.WriteString("ss")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,174 @@
+package fake_test
+
+import (
+	"net/mail"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sample draws one of every kind of fixture from f, in a fixed order.
+func sample(f *fake.Faker) []string {
+	from := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return []string{
+		f.Name(),
+		f.Username(),
+		f.Email(),
+		f.Domain(),
+		f.Hostname(),
+		f.Address().String(),
+		f.Phone(),
+		f.Date(from, to).String(),
+		f.UUID(),
+	}
+}
+
+// TestGolden pins the fixtures of a fixed seed, so that changes to the stream
+// or to the embedded data are caught.
+func TestGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		locale string   // Locale of the faker
+		want   []string // Expected sample for seed 42
+	}{
+		{
+			locale: "en_US",
+			want: []string{
+				"Deborah Torres",
+				"msanchez",
+				"jennifer_gonzalez69@example.net",
+				"globalprime.io",
+				"api-11.summitblue.net",
+				"702 Hill Way, Austin, TX 13162",
+				"(942) 344-7412",
+				"1996-05-08 20:15:07 +0000 UTC",
+				"89f1d5e2-c68d-4782-89dc-841168f797e0",
+			},
+		},
+		{
+			locale: "de_DE",
+			want: []string{
+				"Petra Schröder",
+				"swolf",
+				"katharina_bauer69@example.net",
+				"lichtalpen.com",
+				"api-11.datennord.net",
+				"Friedhofallee 70, 13162 Nürnberg",
+				"+49 422 4474121",
+				"1996-05-08 20:15:07 +0000 UTC",
+				"89f1d5e2-c68d-4782-89dc-841168f797e0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := fake.NewWithLocale(42, tt.locale)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sample(f))
+		})
+	}
+}
+
+// TestDeterminism verifies that the same seed reproduces the same fixtures and
+// that different seeds diverge.
+func TestDeterminism(t *testing.T) {
+	t.Parallel()
+
+	for _, locale := range fake.Locales() {
+		t.Run(locale, func(t *testing.T) {
+			t.Parallel()
+
+			first, err := fake.NewWithLocale(42, locale)
+			require.NoError(t, err)
+
+			second, err := fake.NewWithLocale(42, locale)
+			require.NoError(t, err)
+
+			other, err := fake.NewWithLocale(43, locale)
+			require.NoError(t, err)
+
+			for range 20 {
+				want := sample(first)
+				assert.Equal(t, want, sample(second))
+				assert.NotEqual(t, want, sample(other))
+			}
+		})
+	}
+}
+
+// TestFormats verifies that fixtures look like the data they imitate.
+func TestFormats(t *testing.T) {
+	t.Parallel()
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	username := regexp.MustCompile(`^[a-z0-9._]+$`)
+	domain := regexp.MustCompile(`^[a-z]+-?[a-z]+\.[a-z]+$`)
+	hostname := regexp.MustCompile(`^[a-z]+-\d+\.[a-z]+-?[a-z]+\.[a-z]+$`)
+	usPhone := regexp.MustCompile(`^(\([1-9]\d\d\) [1-9]\d\d-\d{4}|[1-9]\d\d-[1-9]\d\d-\d{4}|\+1 [1-9]\d\d [1-9]\d\d \d{4})$`)
+	usAddress := regexp.MustCompile(`^[1-9]\d{0,4} [A-Za-z]+ [A-Za-z]+, [A-Za-z ]+, [A-Z]{2} \d{5}$`)
+
+	f := fake.New(7)
+
+	for range 200 {
+		assert.Regexp(t, uuid, f.UUID())
+		assert.Regexp(t, username, f.Username())
+		assert.Regexp(t, domain, f.Domain())
+		assert.Regexp(t, hostname, f.Hostname())
+		assert.Regexp(t, usPhone, f.Phone())
+		assert.Regexp(t, usAddress, f.Address().String())
+
+		email := f.Email()
+		_, err := mail.ParseAddress(email)
+		require.NoError(t, err, email)
+		assert.Regexp(t, `@example\.(com|net|org)$`, email)
+	}
+}
+
+// TestLocales verifies locale selection and that non-ASCII names are
+// transliterated for usernames.
+func TestLocales(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"de_DE", "en_US"}, fake.Locales())
+
+	_, err := fake.NewWithLocale(1, "xx_XX")
+	require.ErrorIs(t, err, fake.ErrUnknownLocale)
+
+	f, err := fake.NewWithLocale(1, "de_DE")
+	require.NoError(t, err)
+
+	for range 200 {
+		assert.Regexp(t, `^[a-z0-9._]+$`, f.Username())
+
+		address := f.Address()
+		assert.Equal(t, "Deutschland", address.Country)
+		assert.Regexp(t, `^\S.* [1-9]\d{0,2}, \d{5} .+$`, address.String())
+	}
+}
+
+// TestDate verifies that dates fall inside the requested range.
+func TestDate(t *testing.T) {
+	t.Parallel()
+
+	f := fake.New(99)
+	from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for range 500 {
+		date := f.Date(from, to)
+		assert.False(t, date.Before(from))
+		assert.True(t, date.Before(to))
+	}
+
+	assert.Equal(t, to, f.Date(to, from))
+}
@@ -144,3 +144,29 @@ func FuzzSeededDeterminism(f *testing.F) {
 		}
 	})
 }
+
+// FuzzBaseNRoundTrip uses fuzzing to verify that BaseN decodes every encoded
+// input back to the original bytes, for any valid alphabet.
+func FuzzBaseNRoundTrip(f *testing.F) {
+	// Add seed corpus
+	f.Add([]byte("Hello World!"), strand.AlphaNumeric)
+	f.Add([]byte{0, 0, 1}, strand.Base58Alphabet)
+	f.Add([]byte{0xff, 0xff}, "01")
+
+	// Fuzz test
+	f.Fuzz(func(t *testing.T, input []byte, alphabet string) {
+		codec, err := strand.NewBaseN(alphabet)
+		if err != nil {
+			return
+		}
+
+		decoded, err := codec.Decode(codec.Encode(input))
+		if err != nil {
+			t.Fatalf("Failed to decode: %v", err)
+		}
+
+		if !bytes.Equal(input, decoded) {
+			t.Errorf("Round trip mismatch for %x with alphabet %q: got %x", input, alphabet, decoded)
+		}
+	})
+}
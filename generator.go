@@ -0,0 +1,168 @@
+package strand
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// Generator produces random output from an injectable source of randomness.
+//
+// The package-level functions such as Bytes, String and Token use a Generator
+// backed by crypto/rand. Creating a Generator explicitly lets code under test
+// receive scripted, constant or failing sources instead, such as those provided
+// by the strandtest package, while production code passes crypto/rand.Reader.
+//
+// A Generator is safe for concurrent use if its reader is.
+type Generator struct {
+	reader io.Reader
+}
+
+// NewGenerator creates a Generator that reads randomness from r.
+//
+// Parameters:
+//   - r: the source of random bytes. Use crypto/rand.Reader for
+//     security-sensitive applications.
+//
+// Returns a Generator whose output is exactly as random as r.
+func NewGenerator(r io.Reader) *Generator {
+	return &Generator{reader: r}
+}
+
+// defaultGenerator returns the Generator backed by crypto/rand used by the
+// package-level functions.
+func defaultGenerator() *Generator {
+	return NewGenerator(rand.Reader)
+}
+
+// Bytes generates a random byte slice using characters from the provided charset.
+// It behaves like the package-level Bytes function but reads from the
+// Generator's source.
+func (g *Generator) Bytes(size int, charset string) ([]byte, error) {
+	return g.BytesWithContext(context.Background(), size, charset)
+}
+
+// BytesWithContext works like Bytes but accepts a context for cancellation support.
+func (g *Generator) BytesWithContext(ctx context.Context, size int, charset string) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to created secure random bytes due to context ending early: %w", ctx.Err())
+	default:
+		if size <= 0 {
+			return nil, ErrInvalidSize
+		}
+
+		if len(charset) == 0 {
+			return nil, ErrEmptyCharset
+		}
+
+		return g.fill(make([]byte, size), charset)
+	}
+}
+
+// fill replaces every byte of dst with a character drawn uniformly from charset.
+//
+// A random byte b selects charset[b%len(charset)]. Bytes at or above the largest
+// multiple of the charset length that fits in a byte are rejected and redrawn,
+// since keeping them would favor the start of the charset. Charsets whose length
+// divides 256 never reject, so their output is a plain per-byte mapping.
+func (g *Generator) fill(dst []byte, charset string) ([]byte, error) {
+	n := len(charset)
+
+	if n > 256 {
+		src := g.source()
+
+		for i := range dst {
+			index, err := src.intN(n)
+			if err != nil {
+				return nil, err
+			}
+
+			dst[i] = charset[index]
+		}
+
+		return dst, nil
+	}
+
+	limit := 256 - 256%n
+	filled := 0
+
+	for filled < len(dst) {
+		// Only request what is still missing, so that scripted sources are
+		// consumed byte for byte.
+		batch := dst[filled:]
+		if _, err := io.ReadFull(g.reader, batch); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRandomFailure, err)
+		}
+
+		for _, b := range batch {
+			if int(b) < limit {
+				dst[filled] = charset[int(b)%n]
+				filled++
+			}
+		}
+	}
+
+	return dst, nil
+}
+
+// String generates a random string using characters from the provided charset.
+// It behaves like the package-level String function but reads from the
+// Generator's source.
+func (g *Generator) String(size int, charset string) (string, error) {
+	return g.StringWithContext(context.Background(), size, charset)
+}
+
+// StringWithContext works like String but accepts a context for cancellation support.
+func (g *Generator) StringWithContext(ctx context.Context, size int, charset string) (string, error) {
+	nonce, err := g.BytesWithContext(ctx, size, charset)
+	if err != nil {
+		return "", err
+	}
+
+	return string(nonce), nil
+}
+
+// Token generates size random bytes and returns them encoded with enc.
+// It behaves like the package-level Token function but reads from the
+// Generator's source.
+func (g *Generator) Token(size int, enc Encoding) (string, error) {
+	token, _, err := g.TokenBytesWithContext(context.Background(), size, enc)
+
+	return token, err
+}
+
+// TokenBytesWithContext works like Token but also returns the raw bytes and
+// accepts a context for cancellation support.
+func (g *Generator) TokenBytesWithContext(ctx context.Context, size int, enc Encoding) (string, []byte, error) {
+	if !enc.valid() {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, enc)
+	}
+
+	raw, err := g.read(ctx, size)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return enc.EncodeToString(raw), raw, nil
+}
+
+// read returns size bytes read directly from the Generator's source.
+func (g *Generator) read(ctx context.Context, size int) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to generate token due to context ending early: %w", ctx.Err())
+	default:
+		if size <= 0 {
+			return nil, ErrInvalidSize
+		}
+
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(g.reader, raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRandomFailure, err)
+		}
+
+		return raw, nil
+	}
+}
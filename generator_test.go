@@ -0,0 +1,96 @@
+package strand_test
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/strandtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerator verifies that a Generator backed by crypto/rand behaves like the
+// package-level functions.
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewGenerator(rand.Reader)
+
+	b, err := gen.Bytes(16, strand.AlphaNumeric)
+	require.NoError(t, err)
+	assert.Len(t, b, 16)
+	assert.True(t, onlyContains(string(b), strand.AlphaNumeric))
+
+	s, err := gen.String(16, strand.Symbols)
+	require.NoError(t, err)
+	assert.Len(t, s, 16)
+	assert.True(t, onlyContains(s, strand.Symbols))
+
+	token, raw, err := gen.TokenBytesWithContext(context.Background(), 8, strand.Hex)
+	require.NoError(t, err)
+	assert.Equal(t, strand.Hex.EncodeToString(raw), token)
+
+	_, err = gen.String(0, strand.Symbols)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = gen.String(4, "")
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = gen.Token(4, strand.Encoding(-1))
+	require.ErrorIs(t, err, strand.ErrInvalidEncoding)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = gen.StringWithContext(ctx, 4, strand.Numbers)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestGeneratorInjectedSource verifies that output is fully determined by the
+// injected source.
+func TestGeneratorInjectedSource(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewGenerator(strandtest.NewScriptedSource(strandtest.ScriptFor(t, strand.AlphaNumeric, "Strand42")))
+
+	value, err := gen.String(8, strand.AlphaNumeric)
+	require.NoError(t, err)
+	assert.Equal(t, "Strand42", value)
+
+	_, err = strand.NewGenerator(strandtest.NewFailingSource()).Token(8, strand.Base58)
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+}
+
+// TestGeneratorRejectsBiasedBytes verifies that bytes which would favor the
+// start of the charset are redrawn, and that 256-character charsets work.
+func TestGeneratorRejectsBiasedBytes(t *testing.T) {
+	t.Parallel()
+
+	// 250 is the largest multiple of 10 that fits in a byte, so 250-255 would
+	// otherwise map onto "0"-"5" a second time.
+	source := strandtest.NewScriptedSource([]byte{250, 255, 7, 249})
+
+	value, err := strand.NewGenerator(source).String(2, strand.Numbers)
+	require.NoError(t, err)
+	assert.Equal(t, "79", value)
+	assert.Zero(t, source.Remaining())
+
+	every := make([]byte, 256)
+	for i := range every {
+		every[i] = byte(i)
+	}
+
+	digits, err := strand.NewGenerator(strandtest.NewScriptedSource(every)).String(250, strand.Numbers)
+	require.NoError(t, err)
+
+	for _, digit := range strand.Numbers {
+		assert.Equal(t, 25, strings.Count(digits, string(digit)), "digit %q", digit)
+	}
+
+	all, err := strand.NewGenerator(strandtest.NewScriptedSource([]byte{0, 255})).Bytes(2, string(every))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 255}, all)
+}
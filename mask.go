@@ -0,0 +1,132 @@
+package strand
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode/utf8"
+)
+
+// maskDomain separates the keys used by Masker from other uses of HMAC-SHA256.
+const maskDomain = "strand-mask-v1"
+
+// SymbolMode selects how a Masker treats characters from Symbols.
+type SymbolMode int
+
+const (
+	// KeepSymbols leaves symbols unchanged, so that separators in values such as
+	// "+1 (555) 010-4477" or "jane.doe@example.com" survive masking.
+	KeepSymbols SymbolMode = iota
+
+	// ReplaceSymbols replaces every symbol with a random symbol.
+	ReplaceSymbols
+)
+
+// MaskOptions controls how a Masker replaces characters.
+type MaskOptions struct {
+	// Symbols selects how characters from Symbols are treated. Defaults to
+	// KeepSymbols.
+	Symbols SymbolMode
+}
+
+// Masker replaces strings with pseudonyms of the same shape, for anonymizing
+// production data before it is copied to other environments.
+//
+// Every uppercase letter is replaced with a random uppercase letter, every
+// lowercase letter with a lowercase letter and every digit with a digit.
+// Symbols are kept or replaced according to MaskOptions, and every other
+// character, including spaces and non-ASCII characters, is kept. The result
+// therefore has the same length and layout as the input.
+//
+// The replacements are drawn from a ChaCha8 stream keyed with an HMAC of the
+// whole input, so the same input always maps to the same pseudonym under the
+// same key, even across tables and runs, while inputs sharing a prefix map to
+// unrelated pseudonyms. Without the key, a pseudonym reveals nothing about the
+// input beyond its shape.
+//
+// Masking is one-way and not collision-free: distinct inputs can map to the
+// same pseudonym, which becomes likely for short inputs.
+//
+// A Masker is immutable and safe for concurrent use.
+type Masker struct {
+	key  []byte
+	opts MaskOptions
+}
+
+// NewMasker creates a Masker.
+//
+// Parameters:
+//   - key: high-entropy key material of at least MinDerivationKeySize bytes.
+//     Keep it secret: anyone holding it can confirm guesses of masked values.
+//   - opts: how characters are replaced.
+//
+// Returns:
+//   - *Masker: the masker.
+//   - error: ErrInvalidDerivationKey if the key is too short.
+func NewMasker(key []byte, opts MaskOptions) (*Masker, error) {
+	if len(key) < MinDerivationKeySize {
+		return nil, fmt.Errorf("%w: must be at least %d bytes", ErrInvalidDerivationKey, MinDerivationKeySize)
+	}
+
+	return &Masker{key: append([]byte(nil), key...), opts: opts}, nil
+}
+
+// Mask returns the pseudonym of input with the default options.
+//
+// It is a shorthand for creating a Masker with NewMasker and calling its Mask
+// method, and returns the same errors as NewMasker.
+func Mask(input string, key []byte) (string, error) {
+	m, err := NewMasker(key, MaskOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return m.Mask(input), nil
+}
+
+// Mask returns the pseudonym of input.
+func (m *Masker) Mask(input string) string {
+	mac := hmac.New(sha256.New, m.key)
+	mac.Write([]byte(maskDomain))
+	mac.Write([]byte(input))
+
+	src := seededSource{rng: rand.New(rand.NewChaCha8([32]byte(mac.Sum(nil))))} //nolint:gosec // ChaCha8 is cryptographically strong.
+
+	var b strings.Builder
+	b.Grow(len(input))
+
+	for i := 0; i < len(input); {
+		r, width := utf8.DecodeRuneInString(input[i:])
+
+		charset := m.charsetOf(r)
+		if charset == "" {
+			b.WriteString(input[i : i+width])
+		} else {
+			// The seeded source never fails.
+			n, _ := src.intN(len(charset))
+			b.WriteByte(charset[n])
+		}
+
+		i += width
+	}
+
+	return b.String()
+}
+
+// charsetOf returns the charset r is replaced from, or "" if r is kept.
+func (m *Masker) charsetOf(r rune) string {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return UppercaseAlphabet
+	case r >= 'a' && r <= 'z':
+		return LowercaseAlphabet
+	case r >= '0' && r <= '9':
+		return Numbers
+	case m.opts.Symbols == ReplaceSymbols && r < utf8.RuneSelf && strings.ContainsRune(Symbols, r):
+		return Symbols
+	default:
+		return ""
+	}
+}
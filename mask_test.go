@@ -0,0 +1,98 @@
+package strand_test
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMaskGolden pins masked output, which must never change between releases
+// so that data masked in separate runs stays joinable.
+func TestMaskGolden(t *testing.T) {
+	t.Parallel()
+
+	masked, err := strand.Mask("Jane Doe, 42 Main St.", masterKey(7))
+	require.NoError(t, err)
+	assert.Equal(t, "Sunf Axw, 16 Vjmg Gb.", masked)
+}
+
+// TestMaskShape verifies that every character keeps its class and position.
+func TestMaskShape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string             // Description of the test case
+		input string             // Value to mask
+		opts  strand.MaskOptions // Options under test
+	}{
+		{name: "email", input: "jane.doe+test@example.com", opts: strand.MaskOptions{}},
+		{name: "phone", input: "+1 (555) 010-4477", opts: strand.MaskOptions{}},
+		{name: "card", input: "4111 1111 1111 1111", opts: strand.MaskOptions{}},
+		{name: "non-ASCII", input: "Jürgen Müßig, Straße 5", opts: strand.MaskOptions{}},
+		{name: "replaced symbols", input: "p@ss-w0rd!#", opts: strand.MaskOptions{Symbols: strand.ReplaceSymbols}},
+		{name: "empty", input: "", opts: strand.MaskOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := strand.NewMasker(masterKey(1), tt.opts)
+			require.NoError(t, err)
+
+			masked := m.Mask(tt.input)
+			want, got := []rune(tt.input), []rune(masked)
+			require.Len(t, got, len(want))
+			assert.Len(t, masked, len(tt.input))
+
+			for i := range want {
+				switch {
+				case want[i] >= 'A' && want[i] <= 'Z':
+					assert.True(t, got[i] >= 'A' && got[i] <= 'Z', "position %d", i)
+				case want[i] >= 'a' && want[i] <= 'z':
+					assert.True(t, got[i] >= 'a' && got[i] <= 'z', "position %d", i)
+				case unicode.IsDigit(want[i]):
+					assert.True(t, unicode.IsDigit(got[i]), "position %d", i)
+				case tt.opts.Symbols == strand.ReplaceSymbols:
+					assert.Contains(t, strand.Symbols, string(got[i]), "position %d", i)
+				default:
+					assert.Equal(t, want[i], got[i], "position %d", i)
+				}
+			}
+		})
+	}
+}
+
+// TestMaskDeterminism verifies that masking is keyed and deterministic.
+func TestMaskDeterminism(t *testing.T) {
+	t.Parallel()
+
+	m, err := strand.NewMasker(masterKey(1), strand.MaskOptions{})
+	require.NoError(t, err)
+
+	other, err := strand.NewMasker(masterKey(2), strand.MaskOptions{})
+	require.NoError(t, err)
+
+	input := "customer-000123456"
+
+	assert.Equal(t, m.Mask(input), m.Mask(input))
+	assert.NotEqual(t, m.Mask(input), other.Mask(input))
+	assert.NotEqual(t, m.Mask(input), input)
+
+	// Inputs that share a prefix must not share a masked prefix.
+	assert.NotEqual(t, m.Mask("customer-000123456")[:12], m.Mask("customer-000123457")[:12])
+}
+
+// TestMaskErrors verifies that short keys are rejected.
+func TestMaskErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.NewMasker([]byte("short"), strand.MaskOptions{})
+	require.ErrorIs(t, err, strand.ErrInvalidDerivationKey)
+
+	_, err = strand.Mask("value", nil)
+	require.ErrorIs(t, err, strand.ErrInvalidDerivationKey)
+}
@@ -0,0 +1,180 @@
+package strand
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// ErrInvalidRange is returned when a numeric range is empty.
+var ErrInvalidRange = errors.New("invalid range: must not be empty")
+
+// Integer is the set of integer types supported by N and Range.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Random produces random numbers. Generator implements it with a
+// cryptographically secure source, and SeededGenerator with a deterministic
+// one, so code that accepts a Random can be given a Generator in production and
+// a SeededGenerator in tests.
+type Random interface {
+	// Uint64 returns a uniformly random 64-bit value.
+	Uint64() (uint64, error)
+
+	// IntN returns a uniformly random value in [0, n).
+	IntN(n int) (int, error)
+
+	// IntRange returns a uniformly random value in [lo, hi], inclusive.
+	IntRange(lo, hi int) (int, error)
+
+	// Float64 returns a uniformly random value in [0.0, 1.0).
+	Float64() (float64, error)
+
+	// Duration returns a uniformly random duration in [lo, hi], inclusive.
+	Duration(lo, hi time.Duration) (time.Duration, error)
+}
+
+// Uint64 returns a cryptographically secure, uniformly random 64-bit value.
+//
+// Returns an error if random generation fails.
+func Uint64() (uint64, error) {
+	return defaultGenerator().Uint64()
+}
+
+// IntN returns a cryptographically secure, uniformly random value in [0, n).
+// Unlike reducing a random value with %, every value is equally likely.
+//
+// Returns ErrInvalidRange if n is not greater than 0, or an error if random
+// generation fails.
+func IntN(n int) (int, error) {
+	return defaultGenerator().IntN(n)
+}
+
+// IntRange returns a cryptographically secure, uniformly random value in
+// [lo, hi], inclusive, such as IntRange(100000, 999999) for a six-digit PIN
+// without a leading zero.
+//
+// Returns ErrInvalidRange if lo is greater than hi, or an error if random
+// generation fails.
+func IntRange(lo, hi int) (int, error) {
+	return defaultGenerator().IntRange(lo, hi)
+}
+
+// Float64 returns a cryptographically secure, uniformly random value in
+// [0.0, 1.0) with 53 bits of precision.
+//
+// Returns an error if random generation fails.
+func Float64() (float64, error) {
+	return defaultGenerator().Float64()
+}
+
+// Duration returns a cryptographically secure, uniformly random duration in
+// [lo, hi], inclusive, such as the jitter added to a retry delay.
+//
+// Returns ErrInvalidRange if lo is greater than hi, or an error if random
+// generation fails.
+func Duration(lo, hi time.Duration) (time.Duration, error) {
+	return defaultGenerator().Duration(lo, hi)
+}
+
+// N returns a uniformly random value of any integer type in [0, n), drawn
+// from r. Pass a Generator for secure output or a SeededGenerator for
+// reproducible output.
+//
+// Returns ErrInvalidRange if n is not greater than 0, or an error if random
+// generation fails.
+func N[T Integer](r Random, n T) (T, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: n must be greater than 0, got %v", ErrInvalidRange, n)
+	}
+
+	v, err := uint64N(r, uint64(n))
+
+	return T(v), err
+}
+
+// Range returns a uniformly random value of any integer type in [lo, hi],
+// inclusive, drawn from r. The whole range of T is allowed.
+//
+// Returns ErrInvalidRange if lo is greater than hi, or an error if random
+// generation fails.
+func Range[T Integer](r Random, lo, hi T) (T, error) {
+	if lo > hi {
+		return 0, fmt.Errorf("%w: %v is greater than %v", ErrInvalidRange, lo, hi)
+	}
+
+	// Conversion to uint64 sign-extends, so the difference is the width of the
+	// range for signed and unsigned types alike.
+	span := uint64(hi) - uint64(lo)
+
+	var (
+		v   uint64
+		err error
+	)
+
+	if span == math.MaxUint64 {
+		v, err = r.Uint64()
+	} else {
+		v, err = uint64N(r, span+1)
+	}
+
+	// The addition wraps around exactly when the result crosses zero.
+	return lo + T(v), err
+}
+
+// uint64N returns a uniformly random value in [0, n) drawn from r.
+func uint64N(r Random, n uint64) (uint64, error) {
+	// Values below threshold would make the low end of the range more likely,
+	// since 2^64 is not generally a multiple of n.
+	threshold := -n % n
+
+	for {
+		v, err := r.Uint64()
+		if err != nil {
+			return 0, err
+		}
+
+		if v >= threshold {
+			return v % n, nil
+		}
+	}
+}
+
+// Uint64 returns a uniformly random 64-bit value read from the Generator's source.
+func (g *Generator) Uint64() (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(g.reader, buf[:]); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrRandomFailure, err)
+	}
+
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// IntN returns a uniformly random value in [0, n) read from the Generator's source.
+func (g *Generator) IntN(n int) (int, error) {
+	return N(g, n)
+}
+
+// IntRange returns a uniformly random value in [lo, hi], inclusive, read from
+// the Generator's source.
+func (g *Generator) IntRange(lo, hi int) (int, error) {
+	return Range(g, lo, hi)
+}
+
+// Float64 returns a uniformly random value in [0.0, 1.0) read from the
+// Generator's source.
+func (g *Generator) Float64() (float64, error) {
+	v, err := g.Uint64()
+
+	return float64(v>>11) / (1 << 53), err
+}
+
+// Duration returns a uniformly random duration in [lo, hi], inclusive, read
+// from the Generator's source.
+func (g *Generator) Duration(lo, hi time.Duration) (time.Duration, error) {
+	return Range(g, lo, hi)
+}
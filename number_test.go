@@ -0,0 +1,183 @@
+package strand_test
+
+import (
+	"crypto/rand"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/stats"
+	"github.com/everlastingbeta/strand/strandtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randoms returns one Random of each implementation.
+func randoms() map[string]strand.Random {
+	return map[string]strand.Random{
+		"Generator":       strand.NewGenerator(rand.Reader),
+		"SeededGenerator": strand.NewSeededGenerator(42),
+	}
+}
+
+// TestRandom verifies that every implementation honors the bounds of each method.
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	for name, r := range randoms() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hit := make(map[int]bool)
+
+			for range 2000 {
+				n, err := r.IntN(7)
+				require.NoError(t, err)
+				assert.True(t, n >= 0 && n < 7, n)
+
+				v, err := r.IntRange(-3, 3)
+				require.NoError(t, err)
+				assert.True(t, v >= -3 && v <= 3, v)
+
+				hit[v] = true
+
+				f, err := r.Float64()
+				require.NoError(t, err)
+				assert.True(t, f >= 0 && f < 1, f)
+
+				d, err := r.Duration(time.Second, 2*time.Second)
+				require.NoError(t, err)
+				assert.True(t, d >= time.Second && d <= 2*time.Second, d)
+			}
+
+			assert.Len(t, hit, 7, "both ends of an inclusive range should be reachable")
+
+			_, err := r.IntN(0)
+			require.ErrorIs(t, err, strand.ErrInvalidRange)
+
+			_, err = r.IntRange(1, 0)
+			require.ErrorIs(t, err, strand.ErrInvalidRange)
+
+			_, err = r.Duration(time.Second, 0)
+			require.ErrorIs(t, err, strand.ErrInvalidRange)
+		})
+	}
+}
+
+// TestIntNUniform verifies that IntN has no modulo bias.
+func TestIntNUniform(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n     = 12
+		draws = 120_000
+	)
+
+	observed := make([]int, n)
+	expected := make([]float64, n)
+
+	for range draws {
+		v, err := strand.IntN(n)
+		require.NoError(t, err)
+
+		observed[v]++
+	}
+
+	for i := range expected {
+		expected[i] = draws / n
+	}
+
+	result, err := stats.ChiSquared(observed, expected)
+	require.NoError(t, err)
+	assert.True(t, result.Pass(qualityAlpha), result.String())
+}
+
+// TestPackageNumbers verifies the package-level functions.
+func TestPackageNumbers(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.Uint64()
+	require.NoError(t, err)
+
+	pin, err := strand.IntRange(100000, 999999)
+	require.NoError(t, err)
+	assert.True(t, pin >= 100000 && pin <= 999999, pin)
+
+	f, err := strand.Float64()
+	require.NoError(t, err)
+	assert.True(t, f >= 0 && f < 1, f)
+
+	jitter, err := strand.Duration(0, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, jitter >= 0 && jitter <= 100*time.Millisecond, jitter)
+
+	_, err = strand.IntN(-1)
+	require.ErrorIs(t, err, strand.ErrInvalidRange)
+}
+
+// TestGenericNumbers verifies N and Range over integer types of every width
+// and signedness, including full ranges.
+func TestGenericNumbers(t *testing.T) {
+	t.Parallel()
+
+	r := strand.NewSeededGenerator(7)
+
+	seen := make(map[int8]bool)
+	for range 5000 {
+		v, err := strand.Range[int8](r, math.MinInt8, math.MaxInt8)
+		require.NoError(t, err)
+
+		seen[v] = true
+	}
+
+	assert.Len(t, seen, 256)
+
+	u, err := strand.Range[uint64](r, 0, math.MaxUint64)
+	require.NoError(t, err)
+	assert.NotZero(t, u)
+
+	i, err := strand.Range[int64](r, math.MinInt64, math.MaxInt64)
+	require.NoError(t, err)
+	assert.NotZero(t, i)
+
+	b, err := strand.N[uint8](r, 200)
+	require.NoError(t, err)
+	assert.Less(t, b, uint8(200))
+
+	big, err := strand.N[uint64](r, math.MaxUint64)
+	require.NoError(t, err)
+	assert.Less(t, big, uint64(math.MaxUint64))
+
+	same, err := strand.Range[int16](r, -5, -5)
+	require.NoError(t, err)
+	assert.Equal(t, int16(-5), same)
+
+	_, err = strand.N[int32](r, -1)
+	require.ErrorIs(t, err, strand.ErrInvalidRange)
+}
+
+// TestNumbersDeterminism verifies that seeded numbers reproduce and that
+// failing sources are reported.
+func TestNumbersDeterminism(t *testing.T) {
+	t.Parallel()
+
+	first, second := strand.NewSeededGenerator(99), strand.NewSeededGenerator(99)
+
+	for range 100 {
+		a, err := first.IntRange(0, 1000)
+		require.NoError(t, err)
+
+		b, err := second.IntRange(0, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, a, b)
+	}
+
+	failing := strand.NewGenerator(strandtest.NewFailingSource())
+
+	_, err := failing.IntN(10)
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+
+	_, err = failing.Float64()
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+}
@@ -0,0 +1,432 @@
+// Package otp provisions and verifies HMAC-based (RFC 4226) and time-based
+// (RFC 6238) one-time passwords.
+//
+// Secrets are generated with strand's cryptographically secure source and can be
+// rendered as otpauth:// provisioning URIs understood by authenticator apps.
+package otp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by RFC 4226 and remains the authenticator default.
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+)
+
+// Common error types for the otp package.
+var (
+	ErrInvalidSecret    = errors.New("invalid secret: must be non-empty base32")
+	ErrInvalidDigits    = errors.New("invalid digits: must be between 6 and 10")
+	ErrInvalidPeriod    = errors.New("invalid period: must be a whole number of seconds greater than 0")
+	ErrInvalidAlgorithm = errors.New("invalid algorithm: must be SHA1, SHA256 or SHA512")
+	ErrInvalidTime      = errors.New("invalid time: must not be before the Unix epoch")
+	ErrInvalidAccount   = errors.New("invalid account: cannot be empty")
+	ErrInvalidCode      = errors.New("invalid code")
+)
+
+const (
+	// DefaultDigits is the number of digits in a code when Config.Digits is zero.
+	DefaultDigits = 6
+
+	// DefaultPeriod is the TOTP time step used when Config.Period is zero.
+	DefaultPeriod = 30 * time.Second
+
+	// DefaultSecretSize is the secret length in bytes recommended by RFC 4226 (160 bits).
+	DefaultSecretSize = 20
+
+	// Base32Alphabet contains the RFC 4648 base32 characters used to encode secrets.
+	Base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+	minDigits = 6
+	maxDigits = 10
+)
+
+// Algorithm identifies the HMAC hash function used to compute codes.
+type Algorithm int
+
+const (
+	// SHA1 computes codes with HMAC-SHA1. It is the default and the only
+	// algorithm supported by every authenticator app.
+	SHA1 Algorithm = iota
+
+	// SHA256 computes codes with HMAC-SHA256.
+	SHA256
+
+	// SHA512 computes codes with HMAC-SHA512.
+	SHA512
+)
+
+// String returns the algorithm name as used in provisioning URIs.
+func (a Algorithm) String() string {
+	switch a {
+	case SHA1:
+		return "SHA1"
+	case SHA256:
+		return "SHA256"
+	case SHA512:
+		return "SHA512"
+	default:
+		return "Algorithm(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// hash returns the constructor for the algorithm's hash function.
+func (a Algorithm) hash() (func() hash.Hash, error) {
+	switch a {
+	case SHA1:
+		return sha1.New, nil
+	case SHA256:
+		return sha256.New, nil
+	case SHA512:
+		return sha512.New, nil
+	default:
+		return nil, ErrInvalidAlgorithm
+	}
+}
+
+// Config controls how codes are computed and verified. The zero value is
+// the configuration used by most authenticator apps: 6 digits, a 30 second
+// period, HMAC-SHA1 and no skew.
+type Config struct {
+	// Digits is the number of digits in a code, between 6 and 10.
+	Digits int
+
+	// Period is the TOTP time step. It must be a whole number of seconds.
+	Period time.Duration
+
+	// Algorithm is the HMAC hash function.
+	Algorithm Algorithm
+
+	// Skew is the number of additional steps accepted during verification.
+	// For TOTP it applies on both sides of the current step to tolerate clock
+	// drift; for HOTP it is the look-ahead window past the expected counter.
+	Skew uint
+}
+
+// normalize fills in defaults for zero fields and validates the result.
+func (c Config) normalize() (Config, error) {
+	if c.Digits == 0 {
+		c.Digits = DefaultDigits
+	}
+
+	if c.Period == 0 {
+		c.Period = DefaultPeriod
+	}
+
+	if c.Digits < minDigits || c.Digits > maxDigits {
+		return c, ErrInvalidDigits
+	}
+
+	if c.Period < time.Second || c.Period%time.Second != 0 {
+		return c, ErrInvalidPeriod
+	}
+
+	if _, err := c.Algorithm.hash(); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// GenerateSecret generates a cryptographically secure secret suitable for
+// HOTP and TOTP.
+//
+// Parameters:
+//   - size: the length of the secret in bytes. Must be greater than 0;
+//     DefaultSecretSize is recommended.
+//
+// Returns:
+//   - []byte: the raw secret.
+//   - error: an error if random generation fails or if size is invalid.
+func GenerateSecret(size int) ([]byte, error) {
+	return GenerateSecretWithContext(context.Background(), size)
+}
+
+// GenerateSecretWithContext works like GenerateSecret but accepts a context
+// for cancellation support.
+//
+// The secret is drawn as base32 characters from strand's crypto source, which
+// maps bytes onto Base32Alphabet without bias, and then decoded to raw bytes.
+func GenerateSecretWithContext(ctx context.Context, size int) ([]byte, error) {
+	if size <= 0 {
+		return nil, strand.ErrInvalidSize
+	}
+
+	encoded, err := strand.StringWithContext(ctx, encoding().EncodedLen(size), Base32Alphabet)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeSecret(encoded)
+}
+
+// EncodeSecret returns the unpadded base32 form of secret, as expected by
+// authenticator apps.
+func EncodeSecret(secret []byte) string {
+	return encoding().EncodeToString(secret)
+}
+
+// DecodeSecret parses a base32 secret. It is lenient towards user input:
+// lowercase letters, spaces, hyphens and trailing padding are accepted.
+func DecodeSecret(secret string) ([]byte, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '=':
+			return -1
+		default:
+			return r
+		}
+	}, strings.ToUpper(secret))
+
+	if cleaned == "" {
+		return nil, ErrInvalidSecret
+	}
+
+	decoded, err := encoding().DecodeString(cleaned)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSecret, err)
+	}
+
+	return decoded, nil
+}
+
+// HOTP computes the RFC 4226 code for secret at the given counter.
+//
+// Parameters:
+//   - secret: the raw shared secret. Cannot be empty.
+//   - counter: the moving factor.
+//   - cfg: the code configuration; Period and Skew are ignored.
+//
+// Returns:
+//   - string: the zero-padded code.
+//   - error: an error if the secret or configuration is invalid.
+func HOTP(secret []byte, counter uint64, cfg Config) (string, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	if len(secret) == 0 {
+		return "", ErrInvalidSecret
+	}
+
+	return compute(secret, counter, cfg), nil
+}
+
+// TOTP computes the RFC 6238 code for secret at time t.
+//
+// Parameters:
+//   - secret: the raw shared secret. Cannot be empty.
+//   - t: the time for which the code is computed.
+//   - cfg: the code configuration; Skew is ignored.
+//
+// Returns:
+//   - string: the zero-padded code.
+//   - error: an error if the secret, time or configuration is invalid.
+func TOTP(secret []byte, t time.Time, cfg Config) (string, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	step, err := timeStep(t, cfg.Period)
+	if err != nil {
+		return "", err
+	}
+
+	return HOTP(secret, step, cfg)
+}
+
+// VerifyHOTP checks code against the counters counter through counter+Skew.
+//
+// Returns:
+//   - uint64: the counter the caller should store for the next verification,
+//     one past the counter that matched.
+//   - error: ErrInvalidCode if no counter in the window matches, or an error
+//     if the secret or configuration is invalid.
+func VerifyHOTP(secret []byte, code string, counter uint64, cfg Config) (uint64, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(secret) == 0 {
+		return 0, ErrInvalidSecret
+	}
+
+	if len(code) != cfg.Digits {
+		return 0, ErrInvalidCode
+	}
+
+	for offset := range uint64(cfg.Skew) + 1 {
+		if equal(compute(secret, counter+offset, cfg), code) {
+			return counter + offset + 1, nil
+		}
+	}
+
+	return 0, ErrInvalidCode
+}
+
+// VerifyTOTP checks code against the time steps within Skew steps of t.
+//
+// Returns:
+//   - uint64: the time step that matched. Callers that need replay protection
+//     should reject codes whose step is not greater than the last accepted one.
+//   - error: ErrInvalidCode if no step in the window matches, or an error
+//     if the secret, time or configuration is invalid.
+func VerifyTOTP(secret []byte, code string, t time.Time, cfg Config) (uint64, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(secret) == 0 {
+		return 0, ErrInvalidSecret
+	}
+
+	step, err := timeStep(t, cfg.Period)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(code) != cfg.Digits {
+		return 0, ErrInvalidCode
+	}
+
+	first := step - min(step, uint64(cfg.Skew))
+	for candidate := first; candidate <= step+uint64(cfg.Skew); candidate++ {
+		if equal(compute(secret, candidate, cfg), code) {
+			return candidate, nil
+		}
+	}
+
+	return 0, ErrInvalidCode
+}
+
+// TOTPURI renders an otpauth://totp provisioning URI, typically displayed as a
+// QR code for authenticator apps to scan.
+//
+// Parameters:
+//   - issuer: the service name shown in the authenticator. May be empty.
+//   - account: the user's account name, such as an email address. Cannot be empty.
+//   - secret: the raw shared secret. Cannot be empty.
+//   - cfg: the code configuration; Skew is ignored.
+func TOTPURI(issuer, account string, secret []byte, cfg Config) (string, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("period", strconv.FormatInt(int64(cfg.Period/time.Second), 10))
+
+	return provisioningURI("totp", issuer, account, secret, cfg, params)
+}
+
+// HOTPURI renders an otpauth://hotp provisioning URI with the given initial counter.
+//
+// Parameters:
+//   - issuer: the service name shown in the authenticator. May be empty.
+//   - account: the user's account name, such as an email address. Cannot be empty.
+//   - secret: the raw shared secret. Cannot be empty.
+//   - counter: the initial counter value.
+//   - cfg: the code configuration; Period and Skew are ignored.
+func HOTPURI(issuer, account string, secret []byte, counter uint64, cfg Config) (string, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("counter", strconv.FormatUint(counter, 10))
+
+	return provisioningURI("hotp", issuer, account, secret, cfg, params)
+}
+
+// provisioningURI assembles an otpauth URI following the Key URI Format used by
+// Google Authenticator and compatible apps.
+func provisioningURI(kind, issuer, account string, secret []byte, cfg Config, params url.Values) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrInvalidSecret
+	}
+
+	if account == "" {
+		return "", ErrInvalidAccount
+	}
+
+	label := account
+	if issuer != "" {
+		label = issuer + ":" + account
+		params.Set("issuer", issuer)
+	}
+
+	params.Set("secret", EncodeSecret(secret))
+	params.Set("algorithm", cfg.Algorithm.String())
+	params.Set("digits", strconv.Itoa(cfg.Digits))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     kind,
+		Path:     "/" + label,
+		RawQuery: params.Encode(),
+	}
+
+	return uri.String(), nil
+}
+
+// compute implements the HOTP algorithm from RFC 4226 section 5.3 on a
+// normalized configuration.
+func compute(secret []byte, counter uint64, cfg Config) string {
+	newHash, _ := cfg.Algorithm.hash()
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(newHash, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation: the low nibble of the last byte selects a 4 byte
+	// window whose top bit is masked off.
+	offset := sum[len(sum)-1] & 0x0f
+	value := uint64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+
+	modulus := uint64(1)
+	for range cfg.Digits {
+		modulus *= 10
+	}
+
+	return fmt.Sprintf("%0*d", cfg.Digits, value%modulus)
+}
+
+// timeStep converts t into the RFC 6238 time counter for the given period.
+func timeStep(t time.Time, period time.Duration) (uint64, error) {
+	unix := t.Unix()
+	if unix < 0 {
+		return 0, ErrInvalidTime
+	}
+
+	return uint64(unix) / uint64(period/time.Second), nil
+}
+
+// equal compares two codes in constant time.
+func equal(expected, actual string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
+
+// encoding returns the unpadded standard base32 encoding used for secrets.
+func encoding() *base32.Encoding {
+	return base32.StdEncoding.WithPadding(base32.NoPadding)
+}
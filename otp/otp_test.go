@@ -0,0 +1,261 @@
+package otp_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/otp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret returns the ASCII secret used by the RFC test vectors, repeated
+// to the key length of the given algorithm.
+func rfcSecret(size int) []byte {
+	return []byte(strings.Repeat("1234567890", 7)[:size])
+}
+
+// TestHOTPVectors verifies HOTP against the test values in RFC 4226 Appendix D.
+func TestHOTPVectors(t *testing.T) {
+	t.Parallel()
+
+	expected := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+
+	for counter, want := range expected {
+		code, err := otp.HOTP(rfcSecret(20), uint64(counter), otp.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "counter %d", counter)
+	}
+}
+
+// TestTOTPVectors verifies TOTP against the test values in RFC 6238 Appendix B.
+func TestTOTPVectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix   int64
+		sha1   string
+		sha256 string
+		sha512 string
+	}{
+		{59, "94287082", "46119246", "90693936"},
+		{1111111109, "07081804", "68084774", "25091201"},
+		{1111111111, "14050471", "67062674", "99943326"},
+		{1234567890, "89005924", "91819424", "93441116"},
+		{2000000000, "69279037", "90698825", "38618901"},
+		{20000000000, "65353130", "77737706", "47863826"},
+	}
+
+	for _, tt := range tests {
+		at := time.Unix(tt.unix, 0)
+
+		for algorithm, want := range map[otp.Algorithm]string{
+			otp.SHA1:   tt.sha1,
+			otp.SHA256: tt.sha256,
+			otp.SHA512: tt.sha512,
+		} {
+			size := map[otp.Algorithm]int{otp.SHA1: 20, otp.SHA256: 32, otp.SHA512: 64}[algorithm]
+
+			code, err := otp.TOTP(rfcSecret(size), at, otp.Config{Digits: 8, Algorithm: algorithm})
+			require.NoError(t, err)
+			assert.Equal(t, want, code, "%s at %d", algorithm, tt.unix)
+		}
+	}
+}
+
+// TestConfigValidation verifies that invalid configurations are rejected.
+func TestConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     otp.Config
+		errType error
+	}{
+		{name: "too few digits", cfg: otp.Config{Digits: 5}, errType: otp.ErrInvalidDigits},
+		{name: "too many digits", cfg: otp.Config{Digits: 11}, errType: otp.ErrInvalidDigits},
+		{name: "sub-second period", cfg: otp.Config{Period: time.Millisecond}, errType: otp.ErrInvalidPeriod},
+		{name: "fractional period", cfg: otp.Config{Period: 1500 * time.Millisecond}, errType: otp.ErrInvalidPeriod},
+		{name: "unknown algorithm", cfg: otp.Config{Algorithm: otp.Algorithm(9)}, errType: otp.ErrInvalidAlgorithm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := otp.TOTP(rfcSecret(20), time.Unix(59, 0), tt.cfg)
+			require.ErrorIs(t, err, tt.errType)
+		})
+	}
+
+	t.Run("empty secret", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := otp.HOTP(nil, 0, otp.Config{})
+		require.ErrorIs(t, err, otp.ErrInvalidSecret)
+	})
+
+	t.Run("time before epoch", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := otp.TOTP(rfcSecret(20), time.Unix(-1, 0), otp.Config{})
+		require.ErrorIs(t, err, otp.ErrInvalidTime)
+	})
+}
+
+// TestVerifyHOTP verifies the look-ahead window and the returned counter.
+func TestVerifyHOTP(t *testing.T) {
+	t.Parallel()
+
+	secret := rfcSecret(20)
+
+	next, err := otp.VerifyHOTP(secret, "755224", 0, otp.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), next)
+
+	// Counter 3 is outside the default window but inside a skew of 3.
+	_, err = otp.VerifyHOTP(secret, "969429", 0, otp.Config{})
+	require.ErrorIs(t, err, otp.ErrInvalidCode)
+
+	next, err = otp.VerifyHOTP(secret, "969429", 0, otp.Config{Skew: 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), next)
+
+	_, err = otp.VerifyHOTP(secret, "12345", 0, otp.Config{})
+	require.ErrorIs(t, err, otp.ErrInvalidCode)
+}
+
+// TestVerifyTOTP verifies that skew tolerates clock drift in both directions.
+func TestVerifyTOTP(t *testing.T) {
+	t.Parallel()
+
+	secret := rfcSecret(20)
+	cfg := otp.Config{Digits: 8}
+
+	step, err := otp.VerifyTOTP(secret, "07081804", time.Unix(1111111109, 0), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1111111109/30), step)
+
+	// 1111111109 and 1111111111 fall in adjacent steps.
+	_, err = otp.VerifyTOTP(secret, "07081804", time.Unix(1111111111, 0), cfg)
+	require.ErrorIs(t, err, otp.ErrInvalidCode)
+
+	cfg.Skew = 1
+	step, err = otp.VerifyTOTP(secret, "07081804", time.Unix(1111111111, 0), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1111111109/30), step)
+
+	step, err = otp.VerifyTOTP(secret, "14050471", time.Unix(1111111109, 0), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1111111111/30), step)
+
+	t.Run("skew at the epoch", func(t *testing.T) {
+		t.Parallel()
+
+		code, err := otp.TOTP(secret, time.Unix(0, 0), otp.Config{})
+		require.NoError(t, err)
+
+		_, err = otp.VerifyTOTP(secret, code, time.Unix(0, 0), otp.Config{Skew: 2})
+		require.NoError(t, err)
+	})
+}
+
+// TestGenerateSecret verifies secret generation and base32 round-tripping.
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{1, 10, otp.DefaultSecretSize, 32, 64} {
+		secret, err := otp.GenerateSecret(size)
+		require.NoError(t, err)
+		assert.Len(t, secret, size)
+
+		decoded, err := otp.DecodeSecret(otp.EncodeSecret(secret))
+		require.NoError(t, err)
+		assert.Equal(t, secret, decoded)
+	}
+
+	_, err := otp.GenerateSecret(0)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = otp.GenerateSecretWithContext(ctx, otp.DefaultSecretSize)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestDecodeSecret verifies that user-typed secrets are accepted.
+func TestDecodeSecret(t *testing.T) {
+	t.Parallel()
+
+	want, err := otp.DecodeSecret("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	require.NoError(t, err)
+	assert.Equal(t, rfcSecret(20), want)
+
+	got, err := otp.DecodeSecret("gezd gnbv gy3t qojq-gezd gnbv gy3t qojq")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = otp.DecodeSecret("")
+	require.ErrorIs(t, err, otp.ErrInvalidSecret)
+
+	_, err = otp.DecodeSecret("not base32!")
+	require.ErrorIs(t, err, otp.ErrInvalidSecret)
+}
+
+// TestProvisioningURIs verifies the otpauth URIs rendered for both OTP types.
+func TestProvisioningURIs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("totp", func(t *testing.T) {
+		t.Parallel()
+
+		uri, err := otp.TOTPURI("Example Co", "alice@example.com", rfcSecret(20), otp.Config{
+			Digits:    8,
+			Period:    60 * time.Second,
+			Algorithm: otp.SHA256,
+		})
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(uri)
+		require.NoError(t, err)
+		assert.Equal(t, "otpauth", parsed.Scheme)
+		assert.Equal(t, "totp", parsed.Host)
+		assert.Equal(t, "/Example Co:alice@example.com", parsed.Path)
+
+		query := parsed.Query()
+		assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", query.Get("secret"))
+		assert.Equal(t, "Example Co", query.Get("issuer"))
+		assert.Equal(t, "SHA256", query.Get("algorithm"))
+		assert.Equal(t, "8", query.Get("digits"))
+		assert.Equal(t, "60", query.Get("period"))
+	})
+
+	t.Run("hotp", func(t *testing.T) {
+		t.Parallel()
+
+		uri, err := otp.HOTPURI("", "bob", rfcSecret(20), 7, otp.Config{})
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(uri)
+		require.NoError(t, err)
+		assert.Equal(t, "hotp", parsed.Host)
+		assert.Equal(t, "/bob", parsed.Path)
+		assert.Equal(t, "7", parsed.Query().Get("counter"))
+		assert.False(t, parsed.Query().Has("issuer"))
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := otp.TOTPURI("Example", "", rfcSecret(20), otp.Config{})
+		require.ErrorIs(t, err, otp.ErrInvalidAccount)
+	})
+}
@@ -0,0 +1,312 @@
+package strand
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+)
+
+const (
+	// batchShardSize is the number of values generated per unit of work by
+	// BatchParallel. Seeded output depends on it, so it must never change.
+	batchShardSize = 256
+
+	// writeChunkSize is the number of bytes generated per unit of work by
+	// WriteParallel. Seeded output depends on it, so it must never change.
+	writeChunkSize = 64 << 10
+
+	// batchDomain and writeDomain separate the shard streams of
+	// SeededBatchParallel and SeededWriteParallel from each other and from
+	// NewSeededSubstream.
+	batchDomain = "strand-batch-v1"
+	writeDomain = "strand-write-v1"
+)
+
+// BatchParallel generates count cryptographically secure random strings of the
+// shape described by spec, spreading the work across goroutines.
+//
+// Parameters:
+//   - ctx: context for cancellation support. Canceling it stops every worker.
+//   - count: the number of strings to generate. Must be greater than 0.
+//   - spec: the length and charset of each string.
+//   - workers: the number of goroutines. Values below 1 use GOMAXPROCS.
+//
+// Returns:
+//   - []string: the generated strings.
+//   - error: an error if random generation fails, if invalid parameters are
+//     provided, or if the context is canceled.
+func BatchParallel(ctx context.Context, count int, spec Spec, workers int) ([]string, error) {
+	g := defaultGenerator()
+
+	return batchParallel(ctx, count, spec, workers, func(_ int, values []string) error {
+		for i := range values {
+			value, err := g.StringWithContext(ctx, spec.Size, spec.Charset)
+			if err != nil {
+				return err
+			}
+
+			values[i] = value
+		}
+
+		return nil
+	})
+}
+
+// SeededBatchParallel generates count deterministic strings of the shape
+// described by spec, spreading the work across goroutines.
+//
+// The strings are split into fixed shards, each drawn from its own stream
+// derived from seed, so the result is identical for any number of workers and
+// on every machine.
+//
+// Parameters:
+//   - ctx: context for cancellation support. Canceling it stops every worker.
+//   - count: the number of strings to generate. Must be greater than 0.
+//   - spec: the length and charset of each string.
+//   - workers: the number of goroutines. Values below 1 use GOMAXPROCS.
+//   - seed: identifies the output.
+//
+// Returns:
+//   - []string: the generated strings.
+//   - error: an error if invalid parameters are provided or if the context is
+//     canceled.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use BatchParallel() instead.
+func SeededBatchParallel(ctx context.Context, count int, spec Spec, workers int, seed int64) ([]string, error) {
+	return batchParallel(ctx, count, spec, workers, func(shard int, values []string) error {
+		g := newDomainSubstream(batchDomain, seed, uint64(shard))
+		for i := range values {
+			values[i] = g.String(spec.Size, spec.Charset)
+		}
+
+		return nil
+	})
+}
+
+// batchParallel validates the parameters, then calls fill for every shard of
+// the result on a pool of workers.
+func batchParallel(ctx context.Context, count int, spec Spec, workers int, fill func(shard int, values []string) error) ([]string, error) {
+	if count <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, count)
+	shards := (count + batchShardSize - 1) / batchShardSize
+
+	err := forEachShard(ctx, shards, workers, func(shard int) error {
+		start := shard * batchShardSize
+
+		return fill(shard, values[start:min(start+batchShardSize, count)])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// forEachShard calls fn for every shard in [0, shards) on a pool of workers. It
+// stops handing out shards after the first error or once ctx ends, and returns
+// that error.
+func forEachShard(ctx context.Context, shards, workers int, fn func(shard int) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	next := make(chan int)
+
+	go func() {
+		defer close(next)
+
+		for shard := range shards {
+			select {
+			case next <- shard:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	for range workerCount(workers, shards) {
+		wg.Go(func() {
+			for shard := range next {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				if err := fn(shard); err != nil {
+					cancel(err)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return fmt.Errorf("failed to generate in parallel: %w", err)
+	}
+
+	return nil
+}
+
+// WriteParallel writes size cryptographically secure random bytes, each
+// selected from charset, to w. The bytes are generated in chunks across
+// goroutines and written in order, so memory use stays bounded regardless of
+// size.
+//
+// Parameters:
+//   - ctx: context for cancellation support. Canceling it stops every worker.
+//   - w: the destination. Only the calling goroutine writes to it.
+//   - size: the number of bytes to write. Must be greater than 0.
+//   - charset: the string of characters from which bytes will be selected. Cannot be empty.
+//   - workers: the number of goroutines. Values below 1 use GOMAXPROCS.
+//
+// Returns:
+//   - int64: the number of bytes written.
+//   - error: an error if random generation or writing fails, if invalid
+//     parameters are provided, or if the context is canceled.
+func WriteParallel(ctx context.Context, w io.Writer, size int64, charset string, workers int) (int64, error) {
+	g := defaultGenerator()
+
+	return writeParallel(ctx, w, size, charset, workers, func(_ uint64, chunk []byte) error {
+		_, err := g.fill(chunk, charset)
+
+		return err
+	})
+}
+
+// SeededWriteParallel writes size deterministic bytes, each selected from
+// charset, to w. It works like WriteParallel, and like SeededBatchParallel its
+// output is identical for any number of workers.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use WriteParallel() instead.
+func SeededWriteParallel(ctx context.Context, w io.Writer, size int64, charset string, workers int, seed int64) (int64, error) {
+	return writeParallel(ctx, w, size, charset, workers, func(chunk uint64, dst []byte) error {
+		copy(dst, newDomainSubstream(writeDomain, seed, chunk).Bytes(len(dst), charset))
+
+		return nil
+	})
+}
+
+// chunkJob is a chunk of output being generated by writeParallel.
+type chunkJob struct {
+	index uint64
+	buf   []byte
+	done  chan error
+}
+
+// writeParallel generates the chunks of the output on a pool of workers and
+// writes them to w in order. At most two chunks per worker are in flight.
+func writeParallel(ctx context.Context, w io.Writer, size int64, charset string, workers int, fill func(chunk uint64, dst []byte) error) (int64, error) {
+	if size <= 0 {
+		return 0, ErrInvalidSize
+	}
+
+	if len(charset) == 0 {
+		return 0, ErrEmptyCharset
+	}
+
+	// Workers must see the cancellation before they are waited on.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := (size + writeChunkSize - 1) / writeChunkSize
+	workers = workerCount(workers, int(min(chunks, math.MaxInt32)))
+
+	jobs := make(chan *chunkJob)
+	pending := make(chan *chunkJob, 2*workers)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+
+		for i := range uint64(chunks) {
+			job := &chunkJob{
+				index: i,
+				buf:   make([]byte, min(writeChunkSize, size-int64(i)*writeChunkSize)),
+				done:  make(chan error, 1),
+			}
+
+			select {
+			case pending <- job:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for range workers {
+		wg.Go(func() {
+			for job := range jobs {
+				if err := ctx.Err(); err != nil {
+					job.done <- err
+
+					continue
+				}
+
+				job.done <- fill(job.index, job.buf)
+			}
+		})
+	}
+
+	var written int64
+
+	for job := range pending {
+		var err error
+
+		select {
+		case err = <-job.done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			return written, fmt.Errorf("failed to generate in parallel: %w", err)
+		}
+
+		n, err := w.Write(job.buf)
+		written += int64(n)
+
+		if err != nil {
+			return written, fmt.Errorf("failed to write generated bytes: %w", err)
+		}
+	}
+
+	// The dispatcher only stops before the last chunk when the context ends. A
+	// cancellation after the last chunk was written is not an error.
+	if err := ctx.Err(); err != nil && written < size {
+		return written, fmt.Errorf("failed to generate in parallel: %w", err)
+	}
+
+	return written, nil
+}
+
+// workerCount returns the number of workers to start for the given number of
+// units of work.
+func workerCount(workers, units int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return max(1, min(workers, units))
+}
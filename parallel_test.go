@@ -0,0 +1,198 @@
+package strand_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatchParallel verifies the shape and uniqueness of crypto batches.
+func TestBatchParallel(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 16, Charset: strand.AlphaNumeric}
+
+	values, err := strand.BatchParallel(context.Background(), 1000, spec, 4)
+	require.NoError(t, err)
+	require.Len(t, values, 1000)
+
+	seen := make(map[string]bool, len(values))
+
+	for _, value := range values {
+		assert.Len(t, value, 16)
+		assert.True(t, onlyContains(value, strand.AlphaNumeric))
+		assert.False(t, seen[value])
+
+		seen[value] = true
+	}
+}
+
+// TestSeededBatchParallel verifies that seeded batches do not depend on the
+// number of workers.
+func TestSeededBatchParallel(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 12, Charset: strand.Alphabet}
+
+	want, err := strand.SeededBatchParallel(context.Background(), 1000, spec, 1, 42)
+	require.NoError(t, err)
+	require.Len(t, want, 1000)
+
+	for _, workers := range []int{0, 3, 8, 64} {
+		got, err := strand.SeededBatchParallel(context.Background(), 1000, spec, workers, 42)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "workers=%d", workers)
+	}
+
+	other, err := strand.SeededBatchParallel(context.Background(), 1000, spec, 1, 43)
+	require.NoError(t, err)
+	assert.NotEqual(t, want, other)
+}
+
+// TestSeededParallelStreams pins the seeded output and verifies that it is not
+// drawn from the streams of NewSeededSubstream.
+func TestSeededParallelStreams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	values, err := strand.SeededBatchParallel(ctx, 2, strand.Spec{Size: 8, Charset: strand.Numbers}, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"56050761", "69788693"}, values)
+
+	var buf bytes.Buffer
+
+	_, err = strand.SeededWriteParallel(ctx, &buf, 8, strand.Numbers, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "81817982", buf.String())
+
+	substream := strand.NewSeededSubstream(7, 0).String(8, strand.Numbers)
+	assert.NotEqual(t, substream, values[0])
+	assert.NotEqual(t, substream, buf.String())
+}
+
+// TestWriteParallel verifies the shape of crypto output.
+func TestWriteParallel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	n, err := strand.WriteParallel(context.Background(), &buf, 200_000, strand.Numbers, 4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200_000), n)
+	assert.Equal(t, 200_000, buf.Len())
+	assert.True(t, onlyContains(buf.String(), strand.Numbers))
+}
+
+// TestSeededWriteParallel verifies that seeded output does not depend on the
+// number of workers.
+func TestSeededWriteParallel(t *testing.T) {
+	t.Parallel()
+
+	const size = 300_001
+
+	var want bytes.Buffer
+
+	_, err := strand.SeededWriteParallel(context.Background(), &want, size, strand.ALL, 1, 7)
+	require.NoError(t, err)
+	require.Equal(t, size, want.Len())
+
+	for _, workers := range []int{0, 2, 5} {
+		var got bytes.Buffer
+
+		n, err := strand.SeededWriteParallel(context.Background(), &got, size, strand.ALL, workers, 7)
+		require.NoError(t, err)
+		assert.Equal(t, int64(size), n)
+		assert.Equal(t, want.Bytes(), got.Bytes(), "workers=%d", workers)
+	}
+}
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// TestParallelErrors verifies parameter validation, cancellation and write
+// failures.
+func TestParallelErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := strand.Spec{Size: 8, Charset: strand.Numbers}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := strand.BatchParallel(ctx, 0, spec, 2)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.SeededBatchParallel(ctx, 10, strand.Spec{Size: 8}, 2, 1)
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.BatchParallel(canceled, 10_000, spec, 2)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = strand.SeededBatchParallel(canceled, 10_000, spec, 2, 1)
+	require.ErrorIs(t, err, context.Canceled)
+
+	var buf bytes.Buffer
+
+	_, err = strand.WriteParallel(ctx, &buf, 0, strand.Numbers, 2)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.SeededWriteParallel(ctx, &buf, 10, "", 2, 1)
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	n, err := strand.WriteParallel(canceled, &buf, 1<<20, strand.Numbers, 2)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, n)
+
+	_, err = strand.SeededWriteParallel(ctx, errWriter{}, 1<<20, strand.Numbers, 2, 1)
+	require.ErrorContains(t, err, "disk full")
+	assert.Zero(t, buf.Len())
+}
+
+// cancelWriter cancels a context after its first write.
+type cancelWriter struct {
+	bytes.Buffer
+
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.cancel()
+
+	return w.Buffer.Write(p)
+}
+
+// TestWriteParallelCancelMidway verifies that a cancellation between chunks
+// reports the bytes already written and leaves no goroutines behind. It does
+// not run in parallel so that the goroutine count is not disturbed.
+func TestWriteParallelCancelMidway(t *testing.T) { //nolint:paralleltest // Counts goroutines.
+	const size = 1 << 20
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &cancelWriter{cancel: cancel}
+
+	n, err := strand.WriteParallel(ctx, w, size, strand.Numbers, 2)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Positive(t, n)
+	assert.Less(t, n, int64(size))
+	assert.Equal(t, int64(w.Len()), n)
+
+	// Eventually runs the condition on a goroutine of its own.
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= before+1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,91 @@
+package strand
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultPassphraseWords is the number of words in a passphrase recommended for
+// general use. It yields about 64 bits of entropy.
+const DefaultPassphraseWords = 8
+
+// Passphrase generates a passphrase of randomly chosen words, such as
+// "lucid-otter-brave-harbor", using a cryptographically secure source.
+//
+// Words are drawn independently and uniformly from the embedded adjective and
+// noun lists, so each word adds about 8 bits of entropy. Use PassphraseEntropy
+// to choose a word count for the strength you need.
+//
+// Parameters:
+//   - words: the number of words. Must be greater than 0.
+//   - separator: the string placed between words.
+//
+// Returns:
+//   - string: the generated passphrase.
+//   - error: an error if random generation fails or if words is invalid.
+func Passphrase(words int, separator string) (string, error) {
+	return PassphraseWithContext(context.Background(), words, separator)
+}
+
+// PassphraseWithContext works like Passphrase but accepts a context for
+// cancellation support.
+func PassphraseWithContext(ctx context.Context, words int, separator string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to generate passphrase due to context ending early: %w", ctx.Err())
+	default:
+		return generatePassphrase(newCryptoSource(), words, separator)
+	}
+}
+
+// SeededPassphrase generates a deterministic passphrase.
+//
+// Parameters:
+//   - words: the number of words. Must be greater than 0.
+//   - separator: the string placed between words.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Passphrase() instead.
+func SeededPassphrase(words int, separator string, seed ...int64) (string, error) {
+	return generatePassphrase(seededSource{rng: newSeededRand(seed...)}, words, separator)
+}
+
+// PassphraseEntropy reports the number of bits of entropy in a passphrase of
+// the given number of words.
+func PassphraseEntropy(words int) float64 {
+	return float64(max(words, 0)) * math.Log2(float64(len(passphraseWords())))
+}
+
+// generatePassphrase draws the words of a passphrase from src.
+func generatePassphrase(src source, words int, separator string) (string, error) {
+	if words <= 0 {
+		return "", ErrInvalidSize
+	}
+
+	list := passphraseWords()
+	parts := make([]string, words)
+
+	for i := range parts {
+		n, err := src.intN(len(list))
+		if err != nil {
+			return "", err
+		}
+
+		parts[i] = list[n]
+	}
+
+	return strings.Join(parts, separator), nil
+}
+
+// passphraseWords returns the words passphrases are drawn from. The adjective
+// and noun lists share no words, so every entry is distinct. Callers must not
+// modify the result.
+var passphraseWords = sync.OnceValue(func() []string { //nolint:gochecknoglobals // Read-only after the first call.
+	return slices.Concat(adjectives(), nouns())
+})
@@ -0,0 +1,50 @@
+package strand_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPassphrase verifies the shape, determinism and entropy of passphrases.
+func TestPassphrase(t *testing.T) {
+	t.Parallel()
+
+	phrase, err := strand.Passphrase(5, " ")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(phrase, " "), 5)
+	assert.True(t, onlyContains(strings.ReplaceAll(phrase, " ", ""), strand.LowercaseAlphabet))
+
+	seeded, err := strand.SeededPassphrase(4, "-", 42)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(seeded, "-"), 4)
+
+	again, err := strand.SeededPassphrase(4, "-", 42)
+	require.NoError(t, err)
+	assert.Equal(t, seeded, again, "Same seed should produce same output")
+
+	assert.InDelta(t, 2*strand.PassphraseEntropy(4), strand.PassphraseEntropy(8), 1e-9)
+	assert.Greater(t, strand.PassphraseEntropy(strand.DefaultPassphraseWords), 64.0)
+}
+
+// TestPassphraseErrors verifies that invalid word counts and canceled contexts
+// are reported.
+func TestPassphraseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.Passphrase(0, "-")
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.SeededPassphrase(-1, "-", 1)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.PassphraseWithContext(ctx, 4, "-")
+	require.ErrorIs(t, err, context.Canceled)
+}
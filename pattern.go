@@ -0,0 +1,240 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidPattern is returned when a pattern mask cannot be compiled.
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// Pattern is a compiled mask that describes the character class of every
+// position of a generated value, such as "AAA-9999-aaaa" or "PRD-####-??".
+//
+// Mask syntax:
+//   - A: an uppercase letter (UppercaseAlphabet).
+//   - a: a lowercase letter (LowercaseAlphabet).
+//   - 9 or #: a digit (Numbers).
+//   - ?: any letter (Alphabet).
+//   - *: any letter or digit (AlphaNumeric).
+//   - !: a symbol (Symbols).
+//   - \: escapes the following character so that it is emitted literally.
+//
+// Every other character is emitted literally. Custom classes passed to
+// CompilePattern add new placeholders or override the defaults above.
+//
+// A Pattern is immutable and safe for concurrent use.
+type Pattern struct {
+	mask   string
+	tokens []patternToken
+}
+
+// patternToken is a single position of a compiled pattern. Exactly one of
+// literal and charset is set.
+type patternToken struct {
+	literal string
+	charset string
+}
+
+// defaultPatternClasses returns the placeholders understood by every pattern.
+func defaultPatternClasses() map[rune]string {
+	return map[rune]string{
+		'A': UppercaseAlphabet,
+		'a': LowercaseAlphabet,
+		'9': Numbers,
+		'#': Numbers,
+		'?': Alphabet,
+		'*': AlphaNumeric,
+		'!': Symbols,
+	}
+}
+
+// CompilePattern parses a mask into a Pattern.
+//
+// Parameters:
+//   - mask: the mask describing each position. Cannot be empty.
+//   - classes: optional custom placeholders, such as {'X': "ABCDEF"}, which are
+//     added to or override the default classes. Class characters are drawn and
+//     matched one byte at a time, so they must be ASCII. May be nil.
+//
+// Returns:
+//   - *Pattern: the compiled pattern.
+//   - error: ErrInvalidPattern if the mask is empty, ends with an unfinished
+//     escape or has a class with non-ASCII characters, or ErrEmptyCharset if a
+//     custom class is empty.
+func CompilePattern(mask string, classes map[rune]string) (*Pattern, error) {
+	if mask == "" {
+		return nil, fmt.Errorf("%w: mask cannot be empty", ErrInvalidPattern)
+	}
+
+	lookup := defaultPatternClasses()
+	for placeholder, charset := range classes {
+		if charset == "" {
+			return nil, fmt.Errorf("%w: class %q", ErrEmptyCharset, placeholder)
+		}
+
+		if !isASCII(charset) {
+			return nil, fmt.Errorf("%w: class %q contains non-ASCII characters", ErrInvalidPattern, placeholder)
+		}
+
+		lookup[placeholder] = charset
+	}
+
+	var tokens []patternToken
+
+	for i := 0; i < len(mask); {
+		r, width := utf8.DecodeRuneInString(mask[i:])
+
+		if r == '\\' {
+			if i+width >= len(mask) {
+				return nil, fmt.Errorf("%w: trailing escape in %q", ErrInvalidPattern, mask)
+			}
+
+			_, escaped := utf8.DecodeRuneInString(mask[i+width:])
+			tokens = append(tokens, patternToken{literal: mask[i+width : i+width+escaped]})
+			i += width + escaped
+
+			continue
+		}
+
+		if charset, ok := lookup[r]; ok {
+			tokens = append(tokens, patternToken{charset: charset})
+		} else {
+			tokens = append(tokens, patternToken{literal: mask[i : i+width]})
+		}
+
+		i += width
+	}
+
+	return &Pattern{mask: mask, tokens: tokens}, nil
+}
+
+// MustCompilePattern works like CompilePattern but panics on error instead of
+// returning it.
+//
+// This function is useful for patterns defined as constants, such as in
+// package-level initialization code.
+func MustCompilePattern(mask string, classes map[rune]string) *Pattern {
+	p, err := CompilePattern(mask, classes)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String returns the mask the pattern was compiled from.
+func (p *Pattern) String() string {
+	return p.mask
+}
+
+// Generate returns a cryptographically secure random value matching the pattern.
+//
+// Returns:
+//   - string: the generated value.
+//   - error: an error if random generation fails.
+//
+// This function uses crypto/rand and is suitable for security-sensitive
+// applications like vouchers and activation codes.
+func (p *Pattern) Generate() (string, error) {
+	return p.GenerateWithContext(context.Background())
+}
+
+// GenerateWithContext works like Generate but accepts a context for
+// cancellation support.
+func (p *Pattern) GenerateWithContext(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to generate pattern due to context ending early: %w", ctx.Err())
+	default:
+		return p.generate(newCryptoSource())
+	}
+}
+
+// SeededGenerate returns a deterministic value matching the pattern.
+//
+// Parameters:
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Generate() instead.
+func (p *Pattern) SeededGenerate(seed ...int64) string {
+	// The seeded source never fails.
+	value, _ := p.generate(seededSource{rng: newSeededRand(seed...)})
+
+	return value
+}
+
+// SeededGenerateWithContext works like SeededGenerate but accepts a context
+// for cancellation support.
+func (p *Pattern) SeededGenerateWithContext(ctx context.Context, seed ...int64) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to generate seeded pattern due to context ending early: %w", ctx.Err())
+	default:
+		return p.SeededGenerate(seed...), nil
+	}
+}
+
+// Match reports whether s could have been generated by the pattern, which makes
+// it suitable for validating user-supplied codes.
+func (p *Pattern) Match(s string) bool {
+	for _, token := range p.tokens {
+		if token.charset == "" {
+			if !strings.HasPrefix(s, token.literal) {
+				return false
+			}
+
+			s = s[len(token.literal):]
+
+			continue
+		}
+
+		if s == "" || strings.IndexByte(token.charset, s[0]) < 0 {
+			return false
+		}
+
+		s = s[1:]
+	}
+
+	return s == ""
+}
+
+// generate builds a value by drawing every class position from src.
+func (p *Pattern) generate(src source) (string, error) {
+	var b strings.Builder
+
+	b.Grow(len(p.tokens))
+
+	for _, token := range p.tokens {
+		if token.charset == "" {
+			b.WriteString(token.literal)
+
+			continue
+		}
+
+		i, err := src.intN(len(token.charset))
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteByte(token.charset[i])
+	}
+
+	return b.String(), nil
+}
+
+// isASCII reports whether every byte of s is an ASCII character.
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
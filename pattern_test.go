@@ -0,0 +1,158 @@
+package strand_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPatternGenerate verifies that generated values match their pattern
+// position by position.
+func TestPatternGenerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string            // Description of the test case
+		mask    string            // Mask to compile
+		classes map[rune]string   // Custom classes
+		length  int               // Expected length of generated values
+		check   func(string) bool // Additional check on generated values
+	}{
+		{
+			name:   "voucher with default classes",
+			mask:   "AAA-9999-aaaa",
+			length: 13,
+			check: func(s string) bool {
+				return onlyContains(s[:3], strand.UppercaseAlphabet) &&
+					s[3] == '-' &&
+					onlyContains(s[4:8], strand.Numbers) &&
+					s[8] == '-' &&
+					onlyContains(s[9:], strand.LowercaseAlphabet)
+			},
+		},
+		{
+			name:   "literal prefix",
+			mask:   "PRD-####-??",
+			length: 11,
+			check: func(s string) bool {
+				return s[:4] == "PRD-" && onlyContains(s[4:8], strand.Numbers) && onlyContains(s[9:], strand.Alphabet)
+			},
+		},
+		{
+			name:   "escaped placeholders",
+			mask:   `\A\9-A9`,
+			length: 5,
+			check: func(s string) bool {
+				return s[:3] == "A9-" && onlyContains(s[3:4], strand.UppercaseAlphabet) && onlyContains(s[4:], strand.Numbers)
+			},
+		},
+		{
+			name:    "custom class",
+			mask:    "0xXXXX",
+			classes: map[rune]string{'X': "ABCDEF"},
+			length:  6,
+			check: func(s string) bool {
+				return s[:2] == "0x" && onlyContains(s[2:], "ABCDEF")
+			},
+		},
+		{
+			name:    "custom class overrides default",
+			mask:    "aaaa",
+			classes: map[rune]string{'a': "xyz"},
+			length:  4,
+			check: func(s string) bool {
+				return onlyContains(s, "xyz")
+			},
+		},
+		{
+			name:   "multi-byte literal",
+			mask:   "€99",
+			length: len("€") + 2,
+			check: func(s string) bool {
+				return s[:len("€")] == "€"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := strand.CompilePattern(tt.mask, tt.classes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.mask, p.String())
+
+			value, err := p.Generate()
+			require.NoError(t, err)
+			assert.Len(t, value, tt.length)
+			assert.True(t, tt.check(value), "unexpected value %q", value)
+			assert.True(t, p.Match(value))
+
+			seeded := p.SeededGenerate(42)
+			assert.Len(t, seeded, tt.length)
+			assert.True(t, tt.check(seeded), "unexpected seeded value %q", seeded)
+			assert.True(t, p.Match(seeded))
+			assert.Equal(t, seeded, p.SeededGenerate(42), "Same seed should produce same output")
+		})
+	}
+}
+
+// TestPatternMatch verifies that Match validates existing codes.
+func TestPatternMatch(t *testing.T) {
+	t.Parallel()
+
+	p := strand.MustCompilePattern("PRD-####-??", nil)
+
+	assert.True(t, p.Match("PRD-1234-ab"))
+	assert.True(t, p.Match("PRD-0000-ZZ"))
+	assert.False(t, p.Match("PRD-1234-a"), "too short")
+	assert.False(t, p.Match("PRD-1234-abc"), "too long")
+	assert.False(t, p.Match("PRX-1234-ab"), "wrong literal")
+	assert.False(t, p.Match("PRD-12a4-ab"), "letter in digit class")
+	assert.False(t, p.Match("PRD-1234-a1"), "digit in letter class")
+	assert.False(t, p.Match(""))
+}
+
+// TestCompilePatternErrors verifies that invalid masks and classes are rejected.
+func TestCompilePatternErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.CompilePattern("", nil)
+	require.ErrorIs(t, err, strand.ErrInvalidPattern)
+
+	_, err = strand.CompilePattern(`AAA\`, nil)
+	require.ErrorIs(t, err, strand.ErrInvalidPattern)
+
+	_, err = strand.CompilePattern("XX", map[rune]string{'X': ""})
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.CompilePattern("XX", map[rune]string{'X': "äöü"})
+	require.ErrorIs(t, err, strand.ErrInvalidPattern, "classes are drawn byte by byte")
+
+	assert.PanicsWithError(t, "invalid pattern: mask cannot be empty", func() {
+		strand.MustCompilePattern("", nil)
+	})
+}
+
+// TestPatternWithContext verifies that the context variants honor cancellation.
+func TestPatternWithContext(t *testing.T) {
+	t.Parallel()
+
+	p := strand.MustCompilePattern("AAAA", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.GenerateWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = p.SeededGenerateWithContext(ctx, 42)
+	require.ErrorIs(t, err, context.Canceled)
+
+	value, err := p.SeededGenerateWithContext(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, p.SeededGenerate(42), value)
+}
@@ -0,0 +1,246 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPrefetcherClosed is returned by Prefetcher.Next after the Prefetcher has
+// been closed or its context has ended.
+var ErrPrefetcherClosed = errors.New("prefetcher is closed")
+
+// DefaultPrefetchCapacity is the number of values a Prefetcher buffers when
+// PrefetcherOptions.Capacity is zero.
+const DefaultPrefetchCapacity = 64
+
+// PrefetcherOptions controls the buffering of a Prefetcher.
+type PrefetcherOptions struct {
+	// Capacity is the number of values kept ready. Defaults to
+	// DefaultPrefetchCapacity.
+	Capacity int
+
+	// LowWatermark is the number of buffered values at or below which the
+	// background goroutine refills the buffer to capacity. Zero means half the
+	// capacity, and a negative value refills only once the buffer is empty.
+	// Must be less than the capacity.
+	LowWatermark int
+
+	// Generator is the source of randomness. Defaults to crypto/rand.
+	Generator *Generator
+}
+
+// PrefetcherStats reports the activity of a Prefetcher.
+type PrefetcherStats struct {
+	// Served is the number of values returned by Next.
+	Served uint64
+
+	// Underflows is the number of calls to Next that found the buffer empty and
+	// had to generate a value inline.
+	Underflows uint64
+
+	// Prefetched is the number of values generated by the background goroutine.
+	Prefetched uint64
+
+	// Failures is the number of times background generation failed.
+	Failures uint64
+
+	// Buffered is the number of values currently ready.
+	Buffered int
+}
+
+// Prefetcher generates values of a fixed Spec ahead of time in a background
+// goroutine, so that Next usually returns a ready value without waiting for the
+// random source. It suits latency-sensitive paths such as issuing session IDs.
+//
+// When the buffer is empty, Next generates a value inline and records an
+// underflow, so it never waits for the background goroutine. Watch
+// Stats().Underflows to size the capacity.
+//
+// Values are held in memory until they are served; use a Prefetcher only where
+// that is acceptable for the secrets involved.
+//
+// A Prefetcher is safe for concurrent use. Call Close to stop the background
+// goroutine.
+type Prefetcher struct {
+	spec         Spec
+	generator    *Generator
+	lowWatermark int
+
+	buf    chan string
+	refill chan struct{}
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+	once   sync.Once
+
+	served     atomic.Uint64
+	underflows atomic.Uint64
+	prefetched atomic.Uint64
+	failures   atomic.Uint64
+}
+
+// NewPrefetcher creates a Prefetcher and starts filling its buffer.
+//
+// Parameters:
+//   - ctx: bounds the lifetime of the background goroutine. When it ends, the
+//     Prefetcher behaves as if Close had been called.
+//   - spec: the length and charset of the values.
+//   - opts: the buffering of the values.
+//
+// Returns:
+//   - *Prefetcher: the prefetcher.
+//   - error: an error if the spec or options are invalid.
+func NewPrefetcher(ctx context.Context, spec Spec, opts PrefetcherOptions) (*Prefetcher, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Capacity == 0 {
+		opts.Capacity = DefaultPrefetchCapacity
+	}
+
+	switch {
+	case opts.LowWatermark == 0:
+		opts.LowWatermark = opts.Capacity / 2
+	case opts.LowWatermark < 0:
+		opts.LowWatermark = 0
+	}
+
+	if opts.Capacity < 0 || opts.LowWatermark >= opts.Capacity {
+		return nil, fmt.Errorf("%w: need low watermark < capacity, got %d and %d", ErrInvalidSize, opts.LowWatermark, opts.Capacity)
+	}
+
+	if opts.Generator == nil {
+		opts.Generator = defaultGenerator()
+	}
+
+	p := &Prefetcher{
+		spec:         spec,
+		generator:    opts.Generator,
+		lowWatermark: opts.LowWatermark,
+		buf:          make(chan string, opts.Capacity),
+		refill:       make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+
+	p.ctx, p.cancel = context.WithCancelCause(ctx)
+
+	go p.run()
+
+	return p, nil
+}
+
+// Next returns a value, taken from the buffer if one is ready and generated
+// inline otherwise.
+//
+// Returns ErrPrefetcherClosed once the Prefetcher has been closed or its
+// context has ended, or an error if inline generation fails.
+func (p *Prefetcher) Next() (string, error) {
+	if cause := context.Cause(p.ctx); cause != nil {
+		if errors.Is(cause, ErrPrefetcherClosed) {
+			return "", ErrPrefetcherClosed
+		}
+
+		return "", fmt.Errorf("%w: %w", ErrPrefetcherClosed, cause)
+	}
+
+	select {
+	case value := <-p.buf:
+		p.served.Add(1)
+
+		if len(p.buf) <= p.lowWatermark {
+			p.signal()
+		}
+
+		return value, nil
+	default:
+		p.underflows.Add(1)
+		p.signal()
+
+		value, err := p.generator.StringWithContext(p.ctx, p.spec.Size, p.spec.Charset)
+		if err != nil {
+			return "", err
+		}
+
+		p.served.Add(1)
+
+		return value, nil
+	}
+}
+
+// Stats returns a snapshot of the Prefetcher's activity.
+func (p *Prefetcher) Stats() PrefetcherStats {
+	return PrefetcherStats{
+		Served:     p.served.Load(),
+		Underflows: p.underflows.Load(),
+		Prefetched: p.prefetched.Load(),
+		Failures:   p.failures.Load(),
+		Buffered:   len(p.buf),
+	}
+}
+
+// Close stops the background goroutine, waits for it to exit and discards the
+// buffered values. It is safe to call more than once.
+func (p *Prefetcher) Close() error {
+	p.once.Do(func() {
+		p.cancel(ErrPrefetcherClosed)
+		<-p.done
+
+		// A concurrent Next may take the last value, so never block here.
+		for {
+			select {
+			case <-p.buf:
+			default:
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// signal asks the background goroutine to refill the buffer without blocking.
+func (p *Prefetcher) signal() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// run fills the buffer to capacity, then sleeps until Next signals that the
+// buffer fell to the low watermark. After a failure it also waits for a signal,
+// so a broken source is retried on demand instead of in a busy loop.
+func (p *Prefetcher) run() {
+	defer close(p.done)
+
+	for {
+		for len(p.buf) < cap(p.buf) {
+			value, err := p.generator.StringWithContext(p.ctx, p.spec.Size, p.spec.Charset)
+			if err != nil {
+				if p.ctx.Err() != nil {
+					return
+				}
+
+				p.failures.Add(1)
+
+				break
+			}
+
+			select {
+			case p.buf <- value:
+				p.prefetched.Add(1)
+			case <-p.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-p.refill:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
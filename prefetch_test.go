@@ -0,0 +1,155 @@
+package strand_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/strandtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrefetcher verifies that values are served from the buffer and that the
+// buffer is refilled once it falls to the low watermark.
+func TestPrefetcher(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 24, Charset: strand.AlphaNumeric}
+
+	p, err := strand.NewPrefetcher(context.Background(), spec, strand.PrefetcherOptions{Capacity: 8, LowWatermark: 2})
+	require.NoError(t, err)
+
+	defer p.Close()
+
+	full := func() bool { return p.Stats().Buffered == 8 }
+	require.Eventually(t, full, 5*time.Second, time.Millisecond)
+
+	seen := make(map[string]bool)
+
+	for range 6 {
+		value, err := p.Next()
+		require.NoError(t, err)
+		assert.Len(t, value, 24)
+		assert.True(t, onlyContains(value, strand.AlphaNumeric))
+		assert.False(t, seen[value])
+
+		seen[value] = true
+	}
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(6), stats.Served)
+	assert.Zero(t, stats.Underflows)
+
+	require.Eventually(t, full, 5*time.Second, time.Millisecond, "buffer should refill below the watermark")
+
+	// A value is counted only after it is buffered, so the count may lag.
+	refilled := func() bool { return p.Stats().Prefetched == 14 }
+	require.Eventually(t, refilled, 5*time.Second, time.Millisecond)
+}
+
+// TestPrefetcherRefillWhenEmpty verifies that a negative low watermark defers
+// the refill until the buffer is empty.
+func TestPrefetcherRefillWhenEmpty(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 8, Charset: strand.Numbers}
+
+	p, err := strand.NewPrefetcher(context.Background(), spec, strand.PrefetcherOptions{Capacity: 4, LowWatermark: -1})
+	require.NoError(t, err)
+
+	defer p.Close()
+
+	full := func() bool { return p.Stats().Buffered == 4 }
+	require.Eventually(t, full, 5*time.Second, time.Millisecond)
+
+	for range 3 {
+		_, err := p.Next()
+		require.NoError(t, err)
+	}
+
+	assert.Never(t, func() bool { return p.Stats().Buffered > 1 }, 50*time.Millisecond, time.Millisecond)
+
+	_, err = p.Next()
+	require.NoError(t, err)
+	require.Eventually(t, full, 5*time.Second, time.Millisecond, "buffer should refill once empty")
+	assert.Zero(t, p.Stats().Underflows)
+}
+
+// TestPrefetcherUnderflow verifies that an empty buffer is counted and that
+// Next falls back to inline generation.
+func TestPrefetcherUnderflow(t *testing.T) {
+	t.Parallel()
+
+	opts := strand.PrefetcherOptions{Capacity: 4, Generator: strand.NewGenerator(strandtest.NewFailingSource())}
+
+	p, err := strand.NewPrefetcher(context.Background(), strand.Spec{Size: 8, Charset: strand.Numbers}, opts)
+	require.NoError(t, err)
+
+	defer p.Close()
+
+	_, err = p.Next()
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(1), stats.Underflows)
+	assert.Zero(t, stats.Served)
+	assert.Zero(t, stats.Buffered)
+	require.Eventually(t, func() bool { return p.Stats().Failures > 0 }, 5*time.Second, time.Millisecond)
+}
+
+// TestPrefetcherClose verifies shutdown through Close and through the context.
+func TestPrefetcherClose(t *testing.T) {
+	t.Parallel()
+
+	spec := strand.Spec{Size: 8, Charset: strand.Numbers}
+
+	p, err := strand.NewPrefetcher(context.Background(), spec, strand.PrefetcherOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Close())
+	require.NoError(t, p.Close())
+	assert.Zero(t, p.Stats().Buffered)
+
+	_, err = p.Next()
+	require.ErrorIs(t, err, strand.ErrPrefetcherClosed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p, err = strand.NewPrefetcher(ctx, spec, strand.PrefetcherOptions{})
+	require.NoError(t, err)
+
+	cancel()
+
+	_, err = p.Next()
+	require.ErrorIs(t, err, strand.ErrPrefetcherClosed)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, p.Close())
+}
+
+// TestPrefetcherErrors verifies that invalid specs and options are rejected.
+func TestPrefetcherErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string                   // Description of the test case
+		spec strand.Spec              // Spec under test
+		opts strand.PrefetcherOptions // Options under test
+		want error                    // Expected error
+	}{
+		{name: "zero size", spec: strand.Spec{Charset: strand.Numbers}, want: strand.ErrInvalidSize},
+		{name: "empty charset", spec: strand.Spec{Size: 8}, want: strand.ErrEmptyCharset},
+		{name: "negative capacity", spec: strand.Spec{Size: 8, Charset: strand.Numbers}, opts: strand.PrefetcherOptions{Capacity: -1}, want: strand.ErrInvalidSize},
+		{name: "watermark at capacity", spec: strand.Spec{Size: 8, Charset: strand.Numbers}, opts: strand.PrefetcherOptions{Capacity: 4, LowWatermark: 4}, want: strand.ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := strand.NewPrefetcher(context.Background(), tt.spec, tt.opts)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
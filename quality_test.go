@@ -0,0 +1,181 @@
+package strand_test
+
+import (
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// qualitySamples is the number of characters drawn for each statistical test.
+	// It is large enough to expose modulo bias in every predefined charset.
+	qualitySamples = 200_000
+
+	// qualityAlpha is the significance level below which output is considered
+	// biased. It is small enough that the crypto tests practically never flake.
+	qualityAlpha = 1e-6
+)
+
+// predefinedCharsets returns every charset exported by the package.
+func predefinedCharsets() map[string]string {
+	return map[string]string{
+		"UppercaseAlphabet": strand.UppercaseAlphabet,
+		"LowercaseAlphabet": strand.LowercaseAlphabet,
+		"Alphabet":          strand.Alphabet,
+		"Numbers":           strand.Numbers,
+		"AlphaNumeric":      strand.AlphaNumeric,
+		"Symbols":           strand.Symbols,
+		"ALL":               strand.ALL,
+	}
+}
+
+// allBytes returns a charset containing every byte value, which turns charset
+// output into raw random bytes suitable for bit-level tests.
+func allBytes() string {
+	charset := make([]byte, 256)
+	for i := range charset {
+		charset[i] = byte(i)
+	}
+
+	return string(charset)
+}
+
+// assertQuality runs the charset-level statistical tests on sample.
+func assertQuality(t *testing.T, sample []byte, charset string) {
+	t.Helper()
+
+	uniformity, err := stats.ChiSquaredUniform(sample, charset)
+	require.NoError(t, err)
+	assert.True(t, uniformity.Pass(qualityAlpha), uniformity.String())
+
+	values, err := stats.Indexes(sample, charset)
+	require.NoError(t, err)
+
+	correlation, err := stats.SerialCorrelation(values)
+	require.NoError(t, err)
+	assert.True(t, correlation.Pass(qualityAlpha), correlation.String())
+
+	runs, err := stats.Runs(values)
+	require.NoError(t, err)
+	assert.True(t, runs.Pass(qualityAlpha), runs.String())
+}
+
+// TestBytesQuality verifies that Bytes output is uniform and independent for
+// every predefined charset.
+func TestBytesQuality(t *testing.T) {
+	t.Parallel()
+
+	for name, charset := range predefinedCharsets() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sample, err := strand.Bytes(qualitySamples, charset)
+			require.NoError(t, err)
+
+			assertQuality(t, sample, charset)
+		})
+	}
+}
+
+// TestSeededBytesQuality verifies that SeededBytes output is uniform and
+// independent for every predefined charset. The seeds are fixed, so this test
+// is deterministic.
+func TestSeededBytesQuality(t *testing.T) {
+	t.Parallel()
+
+	for name, charset := range predefinedCharsets() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assertQuality(t, strand.SeededBytes(qualitySamples, charset, 20240601), charset)
+		})
+	}
+}
+
+// TestDeriveQuality verifies that derived output is uniform and independent for
+// every predefined charset. The inputs are fixed, so this test is deterministic.
+func TestDeriveQuality(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, strand.MinDerivationKeySize)
+
+	for name, charset := range predefinedCharsets() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sample, err := strand.Derive(key, "quality", qualitySamples, charset)
+			require.NoError(t, err)
+
+			assertQuality(t, []byte(sample), charset)
+		})
+	}
+}
+
+// TestAtQuality verifies that consecutive indexes yield uniform, independent
+// output. The seed is fixed, so this test is deterministic.
+func TestAtQuality(t *testing.T) {
+	t.Parallel()
+
+	sample := make([]byte, 0, qualitySamples)
+	for index := uint64(0); len(sample) < qualitySamples; index++ {
+		sample = append(sample, strand.At(20240601, index, 8, strand.ALL)...)
+	}
+
+	assertQuality(t, sample, strand.ALL)
+}
+
+// TestRawBitQuality verifies the bit balance of raw random output.
+func TestRawBitQuality(t *testing.T) {
+	t.Parallel()
+
+	crypto, err := strand.Bytes(qualitySamples, allBytes())
+	require.NoError(t, err)
+
+	_, token, err := strand.TokenBytes(qualitySamples, strand.Hex)
+	require.NoError(t, err)
+
+	for name, sample := range map[string][]byte{
+		"Bytes":       crypto,
+		"SeededBytes": strand.SeededBytes(qualitySamples, allBytes(), 20240601),
+		"Token":       token,
+	} {
+		monobit, err := stats.Monobit(sample)
+		require.NoError(t, err)
+		assert.True(t, monobit.Pass(qualityAlpha), "%s: %v", name, monobit)
+
+		uniformity, err := stats.ChiSquaredUniform(sample, allBytes())
+		require.NoError(t, err)
+		assert.True(t, uniformity.Pass(qualityAlpha), "%s: %v", name, uniformity)
+	}
+}
+
+// TestCodeFormatQuality verifies that case folding does not make letters more
+// likely than digits.
+func TestCodeFormatQuality(t *testing.T) {
+	t.Parallel()
+
+	formats := map[string]struct {
+		format  strand.CodeFormat // Format applied to AlphaNumeric
+		charset string            // Characters of the folded charset
+	}{
+		"upper": {format: strand.CodeFormat{Case: strand.CaseUpper}, charset: strand.Numbers + strand.UppercaseAlphabet},
+		"lower": {format: strand.CodeFormat{Case: strand.CaseLower}, charset: strand.Numbers + strand.LowercaseAlphabet},
+	}
+
+	for name, tt := range formats {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := tt.format.Generate(qualitySamples, strand.AlphaNumeric)
+			require.NoError(t, err)
+			assertQuality(t, []byte(code), tt.charset)
+
+			code, err = tt.format.SeededGenerate(qualitySamples, strand.AlphaNumeric, 20240601)
+			require.NoError(t, err)
+			assertQuality(t, []byte(code), tt.charset)
+		})
+	}
+}
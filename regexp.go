@@ -0,0 +1,288 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// Errors returned when generating strings from regular expressions.
+var (
+	ErrInvalidRegexp       = errors.New("invalid regular expression")
+	ErrUnsatisfiableRegexp = errors.New("unsatisfiable regular expression: no string in the universe matches")
+)
+
+// DefaultMaxRepeat is the upper bound used for unbounded repetitions such as
+// *, + and {n,} when RegexpOptions.MaxRepeat is zero.
+const DefaultMaxRepeat = 10
+
+// RegexpOptions controls how FromRegexp expands a regular expression. The zero
+// value is ready to use.
+type RegexpOptions struct {
+	// MaxRepeat caps unbounded repetitions: * produces 0 to MaxRepeat copies,
+	// + produces 1 to MaxRepeat and {n,} produces n to n+MaxRepeat.
+	// Defaults to DefaultMaxRepeat.
+	MaxRepeat int
+
+	// Universe is the charset that . and open-ended classes, such as negated
+	// classes, are restricted to. Defaults to ALL.
+	Universe string
+}
+
+// FromRegexp generates a cryptographically secure random string matching the
+// regular expression expr, which uses the syntax accepted by the regexp package.
+//
+// Parameters:
+//   - expr: the regular expression to satisfy, such as an OpenAPI pattern field.
+//   - opts: repetition and universe limits.
+//
+// Returns:
+//   - string: a random string matching expr.
+//   - error: ErrInvalidRegexp if expr cannot be parsed, ErrUnsatisfiableRegexp
+//     if no string over the universe can match, or an error if random
+//     generation fails.
+//
+// Anchors and word boundaries are accepted but not enforced, so expressions like
+// `a\bb` may produce strings that do not match.
+func FromRegexp(expr string, opts RegexpOptions) (string, error) {
+	return FromRegexpWithContext(context.Background(), expr, opts)
+}
+
+// FromRegexpWithContext works like FromRegexp but accepts a context for
+// cancellation support.
+func FromRegexpWithContext(ctx context.Context, expr string, opts RegexpOptions) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to generate from regexp due to context ending early: %w", ctx.Err())
+	default:
+		return generateFromRegexp(newCryptoSource(), expr, opts)
+	}
+}
+
+// SeededFromRegexp generates a deterministic string matching the regular
+// expression expr.
+//
+// Parameters:
+//   - expr: the regular expression to satisfy.
+//   - opts: repetition and universe limits.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use FromRegexp() instead.
+func SeededFromRegexp(expr string, opts RegexpOptions, seed ...int64) (string, error) {
+	return generateFromRegexp(seededSource{rng: newSeededRand(seed...)}, expr, opts)
+}
+
+// generateFromRegexp parses expr and expands it with src.
+func generateFromRegexp(src source, expr string, opts RegexpOptions) (string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidRegexp, err)
+	}
+
+	if opts.MaxRepeat <= 0 {
+		opts.MaxRepeat = DefaultMaxRepeat
+	}
+
+	if opts.Universe == "" {
+		opts.Universe = ALL
+	}
+
+	g := regexpGenerator{
+		src:       src,
+		universe:  []rune(opts.Universe),
+		maxRepeat: opts.MaxRepeat,
+	}
+
+	if err := g.generate(re); err != nil {
+		return "", err
+	}
+
+	return g.out.String(), nil
+}
+
+// regexpGenerator walks a parsed regular expression and writes a random
+// matching string to out.
+type regexpGenerator struct {
+	src       source
+	universe  []rune
+	maxRepeat int
+	out       strings.Builder
+}
+
+// generate expands a single node of the syntax tree.
+func (g *regexpGenerator) generate(re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return ErrUnsatisfiableRegexp
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil
+	case syntax.OpLiteral:
+		return g.literal(re)
+	case syntax.OpCharClass:
+		return g.class(re.Rune)
+	case syntax.OpAnyChar:
+		return g.pick(g.universe)
+	case syntax.OpAnyCharNotNL:
+		return g.pick(g.filterUniverse(func(r rune) bool { return r != '\n' }))
+	case syntax.OpCapture:
+		return g.generate(re.Sub[0])
+	case syntax.OpStar:
+		return g.repeat(re.Sub[0], 0, g.maxRepeat)
+	case syntax.OpPlus:
+		return g.repeat(re.Sub[0], 1, max(1, g.maxRepeat))
+	case syntax.OpQuest:
+		return g.repeat(re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		upper := re.Max
+		if upper < 0 {
+			upper = re.Min + g.maxRepeat
+		}
+
+		return g.repeat(re.Sub[0], re.Min, upper)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := g.generate(sub); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case syntax.OpAlternate:
+		i, err := g.src.intN(len(re.Sub))
+		if err != nil {
+			return err
+		}
+
+		return g.generate(re.Sub[i])
+	default:
+		return fmt.Errorf("%w: unsupported operation %v", ErrInvalidRegexp, re.Op)
+	}
+}
+
+// literal writes a literal string, choosing a random case for every rune when
+// the expression is case-insensitive.
+func (g *regexpGenerator) literal(re *syntax.Regexp) error {
+	for _, r := range re.Rune {
+		if re.Flags&syntax.FoldCase == 0 {
+			g.out.WriteRune(r)
+
+			continue
+		}
+
+		orbit := []rune{r}
+		for folded := unicode.SimpleFold(r); folded != r; folded = unicode.SimpleFold(folded) {
+			orbit = append(orbit, folded)
+		}
+
+		if err := g.pick(orbit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// class writes a rune from a character class given as inclusive ranges.
+//
+// Classes that extend to the end of Unicode, which is how negated classes are
+// represented after parsing, are intersected with the universe instead.
+func (g *regexpGenerator) class(ranges []rune) error {
+	if len(ranges) == 0 {
+		return ErrUnsatisfiableRegexp
+	}
+
+	if ranges[len(ranges)-1] == unicode.MaxRune {
+		return g.pick(g.filterUniverse(func(r rune) bool { return inRanges(r, ranges) }))
+	}
+
+	total := 0
+	for i := 0; i < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+
+	n, err := g.src.intN(total)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			g.out.WriteRune(ranges[i] + rune(n))
+
+			return nil
+		}
+
+		n -= size
+	}
+
+	return nil
+}
+
+// repeat expands re between lower and upper times, inclusive.
+func (g *regexpGenerator) repeat(re *syntax.Regexp, lower, upper int) error {
+	count := lower
+
+	if upper > lower {
+		extra, err := g.src.intN(upper - lower + 1)
+		if err != nil {
+			return err
+		}
+
+		count += extra
+	}
+
+	for range count {
+		if err := g.generate(re); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pick writes a random rune from candidates.
+func (g *regexpGenerator) pick(candidates []rune) error {
+	if len(candidates) == 0 {
+		return ErrUnsatisfiableRegexp
+	}
+
+	i, err := g.src.intN(len(candidates))
+	if err != nil {
+		return err
+	}
+
+	g.out.WriteRune(candidates[i])
+
+	return nil
+}
+
+// filterUniverse returns the runes of the universe accepted by keep.
+func (g *regexpGenerator) filterUniverse(keep func(rune) bool) []rune {
+	var filtered []rune
+
+	for _, r := range g.universe {
+		if keep(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// inRanges reports whether r falls in one of the inclusive ranges.
+func inRanges(r rune, ranges []rune) bool {
+	for i := 0; i < len(ranges); i += 2 {
+		if r >= ranges[i] && r <= ranges[i+1] {
+			return true
+		}
+	}
+
+	return false
+}
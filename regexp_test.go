@@ -0,0 +1,121 @@
+package strand_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFromRegexp verifies that generated strings match the expression they were
+// generated from, for both the crypto and seeded sources.
+func TestFromRegexp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string // Description of the test case
+		expr string // Expression to generate from
+	}{
+		{name: "literal", expr: `hello`},
+		{name: "character classes", expr: `[A-Z]{3}-[0-9]{4}`},
+		{name: "perl classes", expr: `\d{3}\s\w+`},
+		{name: "alternation", expr: `(cat|dog|bird)s?`},
+		{name: "unbounded repetition", expr: `a*b+c{2,}`},
+		{name: "any character", expr: `.{5}`},
+		{name: "negated class", expr: `[^a-z]{8}`},
+		{name: "case insensitive", expr: `(?i)strand`},
+		{name: "anchored", expr: `^[a-f0-9]{32}$`},
+		{name: "email-like", expr: `[a-z]{3,10}@[a-z]{3,8}\.(com|org|net)`},
+		{name: "unicode class", expr: `\p{Greek}{4}`},
+		{name: "empty", expr: ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher := regexp.MustCompile(`^(?:` + tt.expr + `)$`)
+
+			for range 50 {
+				value, err := strand.FromRegexp(tt.expr, strand.RegexpOptions{})
+				require.NoError(t, err)
+				assert.Regexp(t, matcher, value)
+			}
+
+			seeded, err := strand.SeededFromRegexp(tt.expr, strand.RegexpOptions{}, 42)
+			require.NoError(t, err)
+			assert.Regexp(t, matcher, seeded)
+
+			again, err := strand.SeededFromRegexp(tt.expr, strand.RegexpOptions{}, 42)
+			require.NoError(t, err)
+			assert.Equal(t, seeded, again, "Same seed should produce same output")
+		})
+	}
+}
+
+// TestFromRegexpOptions verifies that the repetition cap and universe are honored.
+func TestFromRegexpOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("max repeat caps unbounded repetition", func(t *testing.T) {
+		t.Parallel()
+
+		for range 50 {
+			value, err := strand.FromRegexp(`a*`, strand.RegexpOptions{MaxRepeat: 3})
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(value), 3)
+
+			value, err = strand.FromRegexp(`b{2,}`, strand.RegexpOptions{MaxRepeat: 3})
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, len(value), 2)
+			assert.LessOrEqual(t, len(value), 5)
+		}
+	})
+
+	t.Run("universe restricts any character", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := strand.FromRegexp(`.{64}`, strand.RegexpOptions{Universe: strand.Numbers})
+		require.NoError(t, err)
+		assert.True(t, onlyContains(value, strand.Numbers))
+	})
+
+	t.Run("universe restricts negated classes", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := strand.FromRegexp(`[^0-4]{64}`, strand.RegexpOptions{Universe: strand.Numbers})
+		require.NoError(t, err)
+		assert.True(t, onlyContains(value, "56789"))
+	})
+
+	t.Run("default universe is ALL", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := strand.FromRegexp(`.{128}`, strand.RegexpOptions{})
+		require.NoError(t, err)
+		assert.True(t, onlyContains(value, strand.ALL))
+	})
+}
+
+// TestFromRegexpErrors verifies that invalid and unsatisfiable expressions are rejected.
+func TestFromRegexpErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.FromRegexp(`[a-`, strand.RegexpOptions{})
+	require.ErrorIs(t, err, strand.ErrInvalidRegexp)
+
+	_, err = strand.FromRegexp(`[^0-9]`, strand.RegexpOptions{Universe: strand.Numbers})
+	require.ErrorIs(t, err, strand.ErrUnsatisfiableRegexp)
+
+	_, err = strand.SeededFromRegexp(`[^\x00-\x{10FFFF}]`, strand.RegexpOptions{}, 42)
+	require.ErrorIs(t, err, strand.ErrUnsatisfiableRegexp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.FromRegexpWithContext(ctx, `abc`, strand.RegexpOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
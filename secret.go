@@ -0,0 +1,226 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Errors returned by Secret and the functions that create one.
+var (
+	ErrSecretDestroyed = errors.New("secret has been destroyed")
+	ErrSecretNotLocked = errors.New("failed to lock secret memory")
+)
+
+// SecretOptions controls how the memory holding a Secret is protected.
+// The zero value locks the memory when the platform allows it.
+type SecretOptions struct {
+	// GuardPages surrounds the value with inaccessible pages on Linux, so that
+	// reads or writes past either end crash the program instead of leaking or
+	// corrupting neighboring memory. It has no effect on other platforms.
+	GuardPages bool
+
+	// RequireLock makes creation fail with ErrSecretNotLocked if the memory
+	// cannot be locked, for example because RLIMIT_MEMLOCK is exhausted or the
+	// platform does not support it. By default locking is best effort.
+	RequireLock bool
+}
+
+// Secret holds a generated value, such as a key or password, outside of the Go
+// heap so that it can be wiped once it is no longer needed.
+//
+// On Linux the value lives in its own memory mapping that is locked into RAM
+// with mlock, so it is never written to swap, and excluded from core dumps. On
+// other platforms it lives in ordinary memory, which can still be zeroed by
+// Destroy. Use Locked to find out which protection applies.
+//
+// The value is only reachable through Use, and String hides it, so it cannot be
+// logged or formatted by accident. Call Destroy as soon as the value is no longer
+// needed; a Secret that becomes unreachable is destroyed by the garbage collector,
+// but only eventually.
+//
+// A Secret is safe for concurrent use.
+type Secret struct {
+	mu      sync.RWMutex
+	mem     *secretMemory
+	locked  bool
+	cleanup runtime.Cleanup
+}
+
+// newSecret allocates a Secret of size bytes and initializes it with init. The
+// memory is released again if init fails.
+func newSecret(size int, opts SecretOptions, init func(value []byte) error) (*Secret, error) {
+	mem, err := allocSecretMemory(size, opts.GuardPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RequireLock && !mem.locked {
+		mem.free()
+
+		return nil, ErrSecretNotLocked
+	}
+
+	if err := init(mem.data); err != nil {
+		mem.free()
+
+		return nil, err
+	}
+
+	s := &Secret{mem: mem, locked: mem.locked}
+	s.cleanup = runtime.AddCleanup(s, (*secretMemory).free, mem)
+
+	return s, nil
+}
+
+// Use calls fn with the value of the secret and returns the error fn returns.
+//
+// The slice passed to fn refers to the protected memory and is only valid until
+// fn returns. fn must not retain it, and copies it makes are not protected.
+//
+// Returns ErrSecretDestroyed without calling fn if the secret has been destroyed.
+func (s *Secret) Use(fn func(value []byte) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.mem == nil {
+		return ErrSecretDestroyed
+	}
+
+	return fn(s.mem.data)
+}
+
+// Len returns the length of the value, or 0 if the secret has been destroyed.
+func (s *Secret) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.mem == nil {
+		return 0
+	}
+
+	return len(s.mem.data)
+}
+
+// Locked reports whether the value is held in memory that is locked into RAM.
+func (s *Secret) Locked() bool {
+	return s.locked
+}
+
+// Destroy zeroes the value and releases its memory. It waits for running calls
+// to Use to return, and is safe to call more than once.
+func (s *Secret) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+
+	s.cleanup.Stop()
+	s.mem.free()
+	s.mem = nil
+}
+
+// String returns a placeholder, so that the value never appears in logs.
+func (s *Secret) String() string {
+	return "strand.Secret(REDACTED)"
+}
+
+// GoString returns a placeholder, so that the value never appears in logs.
+func (s *Secret) GoString() string {
+	return s.String()
+}
+
+// SecretBytes generates a cryptographically secure random value using characters
+// from the provided charset, directly into protected memory.
+//
+// Parameters:
+//   - size: the length of the value. Must be greater than 0.
+//   - charset: the string of characters from which bytes will be selected. Cannot be empty.
+//   - opts: how the memory holding the value is protected.
+//
+// Returns:
+//   - *Secret: the generated value. Call Destroy once it is no longer needed.
+//   - error: an error if random generation or memory allocation fails, if
+//     invalid parameters are provided, or ErrSecretNotLocked if the memory
+//     could not be locked and opts.RequireLock is set.
+func SecretBytes(size int, charset string, opts SecretOptions) (*Secret, error) {
+	return SecretBytesWithContext(context.Background(), size, charset, opts)
+}
+
+// SecretBytesWithContext works like SecretBytes but accepts a context for
+// cancellation support.
+func SecretBytesWithContext(ctx context.Context, size int, charset string, opts SecretOptions) (*Secret, error) {
+	return defaultGenerator().SecretBytesWithContext(ctx, size, charset, opts)
+}
+
+// SecretKey reads size cryptographically secure random bytes directly into
+// protected memory, for use as a key.
+//
+// Parameters:
+//   - size: the number of random bytes. Must be greater than 0.
+//   - opts: how the memory holding the key is protected.
+//
+// Returns:
+//   - *Secret: the generated key. Call Destroy once it is no longer needed.
+//   - error: an error if random generation or memory allocation fails, if size
+//     is invalid, or ErrSecretNotLocked if the memory could not be locked and
+//     opts.RequireLock is set.
+func SecretKey(size int, opts SecretOptions) (*Secret, error) {
+	return SecretKeyWithContext(context.Background(), size, opts)
+}
+
+// SecretKeyWithContext works like SecretKey but accepts a context for
+// cancellation support.
+func SecretKeyWithContext(ctx context.Context, size int, opts SecretOptions) (*Secret, error) {
+	return defaultGenerator().SecretKeyWithContext(ctx, size, opts)
+}
+
+// SecretBytesWithContext works like the package-level SecretBytesWithContext
+// but reads from the Generator's source.
+func (g *Generator) SecretBytesWithContext(ctx context.Context, size int, charset string, opts SecretOptions) (*Secret, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to create secret due to context ending early: %w", ctx.Err())
+	default:
+		if size <= 0 {
+			return nil, ErrInvalidSize
+		}
+
+		if len(charset) == 0 {
+			return nil, ErrEmptyCharset
+		}
+
+		// fill draws in place, so the value never exists outside the secret.
+		return newSecret(size, opts, func(value []byte) error {
+			_, err := g.fill(value, charset)
+
+			return err
+		})
+	}
+}
+
+// SecretKeyWithContext works like the package-level SecretKeyWithContext but
+// reads from the Generator's source.
+func (g *Generator) SecretKeyWithContext(ctx context.Context, size int, opts SecretOptions) (*Secret, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to create secret due to context ending early: %w", ctx.Err())
+	default:
+		if size <= 0 {
+			return nil, ErrInvalidSize
+		}
+
+		return newSecret(size, opts, func(value []byte) error {
+			if _, err := io.ReadFull(g.reader, value); err != nil {
+				return fmt.Errorf("%w: %w", ErrRandomFailure, err)
+			}
+
+			return nil
+		})
+	}
+}
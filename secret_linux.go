@@ -0,0 +1,74 @@
+package strand
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// madvDontDump excludes a mapping from core dumps. It is missing from the
+// syscall package.
+const madvDontDump = 0x10
+
+// secretMemory is a private anonymous mapping holding a secret value.
+//
+// With guard pages the mapping starts and ends with a page that cannot be
+// accessed, and the value is placed at the end of the pages between them, so
+// that running off either end faults immediately.
+type secretMemory struct {
+	region []byte // The whole mapping, including guard pages
+	inner  []byte // The accessible pages
+	data   []byte // The value, at the end of inner
+	locked bool
+}
+
+// allocSecretMemory maps and, if possible, locks memory for a value of size bytes.
+func allocSecretMemory(size int, guard bool) (*secretMemory, error) {
+	page := os.Getpagesize()
+	length := (size + page - 1) / page * page
+
+	guardLen := 0
+	if guard {
+		guardLen = page
+	}
+
+	region, err := syscall.Mmap(-1, 0, length+2*guardLen, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE|syscall.MAP_ANON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate secret memory: %w", err)
+	}
+
+	if guard {
+		for _, p := range [][]byte{region[:page], region[len(region)-page:]} {
+			if err := syscall.Mprotect(p, syscall.PROT_NONE); err != nil {
+				_ = syscall.Munmap(region)
+
+				return nil, fmt.Errorf("failed to protect guard page: %w", err)
+			}
+		}
+	}
+
+	inner := region[guardLen : guardLen+length]
+
+	// Both are best effort: older kernels lack MADV_DONTDUMP, and mlock is
+	// limited by RLIMIT_MEMLOCK.
+	_ = syscall.Madvise(inner, madvDontDump)
+	locked := syscall.Mlock(inner) == nil
+
+	return &secretMemory{
+		region: region,
+		inner:  inner,
+		data:   inner[length-size:],
+		locked: locked,
+	}, nil
+}
+
+// free zeroes the value, then unlocks and unmaps the memory.
+func (m *secretMemory) free() {
+	clear(m.data)
+
+	if m.locked {
+		_ = syscall.Munlock(m.inner)
+	}
+
+	_ = syscall.Munmap(m.region)
+}
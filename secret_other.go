@@ -0,0 +1,20 @@
+//go:build !linux
+
+package strand
+
+// secretMemory holds a secret value in ordinary heap memory, which can be zeroed
+// but not locked or guarded on this platform.
+type secretMemory struct {
+	data   []byte
+	locked bool
+}
+
+// allocSecretMemory allocates memory for a value of size bytes.
+func allocSecretMemory(size int, _ bool) (*secretMemory, error) {
+	return &secretMemory{data: make([]byte, size)}, nil
+}
+
+// free zeroes the value.
+func (m *secretMemory) free() {
+	clear(m.data)
+}
@@ -0,0 +1,170 @@
+package strand_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/strandtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSecretBytes verifies generation into protected memory for each set of options.
+func TestSecretBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string               // Description of the test case
+		size int                  // Length of the value
+		opts strand.SecretOptions // Options under test
+	}{
+		{name: "defaults", size: 32, opts: strand.SecretOptions{}},
+		{name: "guard pages", size: 32, opts: strand.SecretOptions{GuardPages: true}},
+		{name: "guard pages spanning pages", size: 10000, opts: strand.SecretOptions{GuardPages: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			secret, err := strand.SecretBytes(tt.size, strand.AlphaNumeric, tt.opts)
+			require.NoError(t, err)
+
+			defer secret.Destroy()
+
+			assert.Equal(t, tt.size, secret.Len())
+			require.NoError(t, secret.Use(func(value []byte) error {
+				assert.Len(t, value, tt.size)
+				strandtest.AssertFromCharset(t, string(value), strand.AlphaNumeric)
+
+				return nil
+			}))
+		})
+	}
+}
+
+// TestSecretKey verifies that keys hold the exact bytes read from the source.
+func TestSecretKey(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewGenerator(strandtest.NewScriptedSource([]byte{1, 2, 3, 4}))
+
+	secret, err := gen.SecretKeyWithContext(context.Background(), 4, strand.SecretOptions{GuardPages: true})
+	require.NoError(t, err)
+
+	defer secret.Destroy()
+
+	require.NoError(t, secret.Use(func(value []byte) error {
+		assert.Equal(t, []byte{1, 2, 3, 4}, value)
+
+		return nil
+	}))
+
+	key, err := strand.SecretKey(32, strand.SecretOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 32, key.Len())
+	key.Destroy()
+}
+
+// TestSecretDestroy verifies that a destroyed secret can no longer be used.
+func TestSecretDestroy(t *testing.T) {
+	t.Parallel()
+
+	secret, err := strand.SecretBytes(16, strand.Numbers, strand.SecretOptions{})
+	require.NoError(t, err)
+
+	secret.Destroy()
+	secret.Destroy()
+
+	assert.Zero(t, secret.Len())
+
+	called := false
+	err = secret.Use(func([]byte) error {
+		called = true
+
+		return nil
+	})
+	require.ErrorIs(t, err, strand.ErrSecretDestroyed)
+	assert.False(t, called)
+}
+
+// TestSecretUseError verifies that errors from the callback are returned.
+func TestSecretUseError(t *testing.T) {
+	t.Parallel()
+
+	secret, err := strand.SecretBytes(16, strand.Numbers, strand.SecretOptions{})
+	require.NoError(t, err)
+
+	defer secret.Destroy()
+
+	want := errors.New("callback failed")
+	require.ErrorIs(t, secret.Use(func([]byte) error { return want }), want)
+}
+
+// TestSecretRedaction verifies that formatting never reveals the value.
+func TestSecretRedaction(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewGenerator(strandtest.NewConstantSource(strand.LowercaseAlphabet, 0))
+
+	secret, err := gen.SecretBytesWithContext(context.Background(), 8, strand.LowercaseAlphabet, strand.SecretOptions{})
+	require.NoError(t, err)
+
+	defer secret.Destroy()
+
+	for _, verb := range []string{"%v", "%+v", "%s", "%#v"} {
+		formatted := fmt.Sprintf(verb, secret)
+		assert.NotContains(t, formatted, "aaaaaaaa", verb)
+		assert.Contains(t, formatted, "REDACTED", verb)
+	}
+}
+
+// TestSecretRequireLock verifies that RequireLock only succeeds with locked memory.
+func TestSecretRequireLock(t *testing.T) {
+	t.Parallel()
+
+	secret, err := strand.SecretBytes(16, strand.Numbers, strand.SecretOptions{RequireLock: true})
+	if err != nil {
+		require.ErrorIs(t, err, strand.ErrSecretNotLocked)
+
+		return
+	}
+
+	defer secret.Destroy()
+
+	assert.True(t, secret.Locked())
+}
+
+// TestSecretErrors verifies that invalid parameters and failures are reported.
+func TestSecretErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.SecretBytes(0, strand.Numbers, strand.SecretOptions{})
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.SecretBytes(8, "", strand.SecretOptions{})
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.SecretKey(-1, strand.SecretOptions{})
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	failing := strand.NewGenerator(strandtest.NewFailingSource())
+
+	_, err = failing.SecretBytesWithContext(context.Background(), 8, strand.Numbers, strand.SecretOptions{})
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+
+	_, err = failing.SecretKeyWithContext(context.Background(), 8, strand.SecretOptions{GuardPages: true})
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.SecretBytesWithContext(ctx, 8, strand.Numbers, strand.SecretOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = strand.SecretKeyWithContext(ctx, 8, strand.SecretOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -2,8 +2,11 @@ package strand
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"math/rand/v2"
+	"sync"
 	"time"
 )
 
@@ -22,16 +25,7 @@ import (
 // Security Notice: This function uses math/rand/v2 which is NOT cryptographically
 // secure. For security-sensitive applications, use Bytes() instead.
 func SeededBytes(size int, charset string, seed ...int64) []byte {
-	seedValue := time.Now().UnixNano()
-	if len(seed) > 0 {
-		seedValue = seed[0]
-	}
-
-	// Create a local random number generator with the specified seed
-	// Using the v2 package which has simplified APIs
-	rng := rand.New(rand.NewPCG(uint64(seedValue), uint64(seedValue>>32)))
-
-	return generateSeededBytes(rng, size, charset)
+	return generateSeededBytes(newSeededRand(seed...), size, charset)
 }
 
 // SeededBytesWithContext returns a deterministic byte slice like SeededBytes,
@@ -117,3 +111,382 @@ func generateSeededBytes(rng *rand.Rand, size int, charset string) []byte {
 
 	return nonce
 }
+
+// SeededGenerator draws a continuing deterministic sequence from a single seed.
+//
+// Unlike SeededBytes, which starts over from the seed on every call, successive
+// calls to a SeededGenerator continue where the previous call left off. Its state
+// can be saved with MarshalBinary and restored with UnmarshalBinary, so that a
+// long-running simulation can checkpoint and later resume with exactly the same
+// subsequent output.
+//
+// The first call to Bytes or String returns the same value as SeededBytes or
+// SeededString with the same seed.
+//
+// The zero value has no state and panics when used. Create a SeededGenerator
+// with NewSeededGenerator or NewSeededSubstream, or restore a state into the
+// zero value with UnmarshalBinary before using it.
+//
+// A SeededGenerator is not safe for concurrent use.
+//
+// Security Notice: SeededGenerator uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Generator instead.
+type SeededGenerator struct {
+	pcg *rand.PCG
+	rng *rand.Rand
+}
+
+// NewSeededGenerator creates a SeededGenerator.
+//
+// Parameters:
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+func NewSeededGenerator(seed ...int64) *SeededGenerator {
+	seedValue := time.Now().UnixNano()
+	if len(seed) > 0 {
+		seedValue = seed[0]
+	}
+
+	// Using the v2 package which has simplified APIs
+	pcg := rand.NewPCG(uint64(seedValue), uint64(seedValue>>32))
+
+	return &SeededGenerator{pcg: pcg, rng: rand.New(pcg)}
+}
+
+// Bytes returns the next size bytes of the sequence, each selected from the
+// provided charset. It behaves like SeededBytes for invalid parameters.
+func (g *SeededGenerator) Bytes(size int, charset string) []byte {
+	return generateSeededBytes(g.rng, size, charset)
+}
+
+// String returns the next size characters of the sequence as a string.
+func (g *SeededGenerator) String(size int, charset string) string {
+	return string(g.Bytes(size, charset))
+}
+
+// Passphrase returns a passphrase of the given number of words drawn from the
+// sequence. It behaves like SeededPassphrase for invalid parameters.
+func (g *SeededGenerator) Passphrase(words int, separator string) (string, error) {
+	return generatePassphrase(seededSource{rng: g.rng}, words, separator)
+}
+
+// Uint64 returns the next 64-bit value of the sequence. It never fails; the
+// error is returned to implement Random.
+func (g *SeededGenerator) Uint64() (uint64, error) {
+	return g.rng.Uint64(), nil
+}
+
+// IntN returns the next value of the sequence in [0, n). It returns
+// ErrInvalidRange if n is not greater than 0.
+func (g *SeededGenerator) IntN(n int) (int, error) {
+	return N(g, n)
+}
+
+// IntRange returns the next value of the sequence in [lo, hi], inclusive. It
+// returns ErrInvalidRange if lo is greater than hi.
+func (g *SeededGenerator) IntRange(lo, hi int) (int, error) {
+	return Range(g, lo, hi)
+}
+
+// Float64 returns the next value of the sequence in [0.0, 1.0). It never
+// fails; the error is returned to implement Random.
+func (g *SeededGenerator) Float64() (float64, error) {
+	return g.rng.Float64(), nil
+}
+
+// Duration returns the next duration of the sequence in [lo, hi], inclusive.
+// It returns ErrInvalidRange if lo is greater than hi.
+func (g *SeededGenerator) Duration(lo, hi time.Duration) (time.Duration, error) {
+	return Range(g, lo, hi)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the current
+// state of the generator.
+func (g *SeededGenerator) MarshalBinary() ([]byte, error) {
+	return g.pcg.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It restores a state
+// returned by MarshalBinary, after which the generator produces the same output
+// as the generator the state was taken from. It may be called on the zero value.
+func (g *SeededGenerator) UnmarshalBinary(data []byte) error {
+	pcg := new(rand.PCG)
+	if err := pcg.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("failed to restore seeded generator: %w", err)
+	}
+
+	g.pcg, g.rng = pcg, rand.New(pcg)
+
+	return nil
+}
+
+// NewSeededSubstream creates a SeededGenerator for one of many independent
+// streams derived from a single seed.
+//
+// Give each goroutine or shard its own stream number. Every stream is
+// deterministic on its own, so the output of a concurrent program is the same
+// regardless of scheduling, and adding streams never changes existing ones.
+//
+// Parameters:
+//   - seed: identifies the family of streams.
+//   - stream: identifies the stream within the family.
+//
+// Security Notice: SeededGenerator uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Generator instead.
+func NewSeededSubstream(seed int64, stream uint64) *SeededGenerator {
+	return newDomainSubstream(substreamDomain, seed, stream)
+}
+
+// substreamDomain separates the seeds used by NewSeededSubstream from other
+// uses of SHA-256.
+const substreamDomain = "strand-substream-v1"
+
+// newDomainSubstream works like NewSeededSubstream for the family of streams
+// identified by domain, so that internal users of substreams never share a
+// stream with each other or with callers of NewSeededSubstream.
+func newDomainSubstream(domain string, seed int64, stream uint64) *SeededGenerator {
+	block := make([]byte, 0, len(domain)+16)
+	block = append(block, domain...)
+	block = binary.BigEndian.AppendUint64(block, uint64(seed))
+	block = binary.BigEndian.AppendUint64(block, stream)
+
+	sum := sha256.Sum256(block)
+	pcg := rand.NewPCG(binary.LittleEndian.Uint64(sum[:8]), binary.LittleEndian.Uint64(sum[8:16]))
+
+	return &SeededGenerator{pcg: pcg, rng: rand.New(pcg)}
+}
+
+// SharedSeededGenerator is a SeededGenerator that many goroutines can draw from
+// at once.
+//
+// Every call takes the next values of a single sequence under a lock, so the
+// sequence as a whole is exactly that of a SeededGenerator with the same seed.
+// Which goroutine receives which part of it depends on scheduling. When that
+// matters, give each goroutine its own stream from NewSeededSubstream instead.
+//
+// Like that of SeededGenerator, the zero value panics when used until a state
+// is restored into it with UnmarshalBinary.
+//
+// A SharedSeededGenerator is safe for concurrent use.
+//
+// Security Notice: SharedSeededGenerator uses math/rand/v2 which is NOT
+// cryptographically secure. For security-sensitive applications, use Generator
+// instead.
+type SharedSeededGenerator struct {
+	mu sync.Mutex
+	g  *SeededGenerator
+}
+
+// NewSharedSeededGenerator creates a SharedSeededGenerator.
+//
+// Parameters:
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+func NewSharedSeededGenerator(seed ...int64) *SharedSeededGenerator {
+	return &SharedSeededGenerator{g: NewSeededGenerator(seed...)}
+}
+
+// Bytes returns the next size bytes of the sequence, each selected from the
+// provided charset. It behaves like SeededBytes for invalid parameters.
+func (s *SharedSeededGenerator) Bytes(size int, charset string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.Bytes(size, charset)
+}
+
+// String returns the next size characters of the sequence as a string.
+func (s *SharedSeededGenerator) String(size int, charset string) string {
+	return string(s.Bytes(size, charset))
+}
+
+// Uint64 returns the next 64-bit value of the sequence. It never fails; the
+// error is returned to implement Random.
+func (s *SharedSeededGenerator) Uint64() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.Uint64()
+}
+
+// IntN returns the next value of the sequence in [0, n). It returns
+// ErrInvalidRange if n is not greater than 0.
+func (s *SharedSeededGenerator) IntN(n int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.IntN(n)
+}
+
+// IntRange returns the next value of the sequence in [lo, hi], inclusive. It
+// returns ErrInvalidRange if lo is greater than hi.
+func (s *SharedSeededGenerator) IntRange(lo, hi int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.IntRange(lo, hi)
+}
+
+// Float64 returns the next value of the sequence in [0.0, 1.0). It never
+// fails; the error is returned to implement Random.
+func (s *SharedSeededGenerator) Float64() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.Float64()
+}
+
+// Duration returns the next duration of the sequence in [lo, hi], inclusive.
+// It returns ErrInvalidRange if lo is greater than hi.
+func (s *SharedSeededGenerator) Duration(lo, hi time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.Duration(lo, hi)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the current
+// state of the sequence.
+func (s *SharedSeededGenerator) MarshalBinary() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.g.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It restores a state
+// returned by MarshalBinary of a SharedSeededGenerator or SeededGenerator. It
+// may be called on the zero value.
+func (s *SharedSeededGenerator) UnmarshalBinary(data []byte) error {
+	var g SeededGenerator
+	if err := g.UnmarshalBinary(data); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.g = &g
+
+	return nil
+}
+
+// At returns the string at the given index of the deterministic sequence
+// identified by seed, without generating the strings before it.
+//
+// Every (seed, index) pair hashes to the key of its own ChaCha8 stream, so each
+// string is computed directly, is independent of its neighbors, and is the same
+// on every machine. This suits sharded fixture generation, where each worker
+// produces its own range of indexes.
+//
+// Parameters:
+//   - seed: identifies the sequence.
+//   - index: the position in the sequence.
+//   - size: the length of the string to be returned.
+//   - charset: the string of characters from which the string will be generated.
+//
+// Returns a string of the specified size with characters from the charset.
+//
+// Security Notice: Anyone who knows the seed can compute every string, so the
+// output is NOT secret. For security-sensitive applications, use Derive() instead.
+func At(seed int64, index uint64, size int, charset string) string {
+	var block [len(atDomain) + 16]byte
+
+	copy(block[:], atDomain)
+	binary.BigEndian.PutUint64(block[len(atDomain):], uint64(seed))
+	binary.BigEndian.PutUint64(block[len(atDomain)+8:], index)
+
+	return string(generateSeededBytes(rand.New(rand.NewChaCha8(sha256.Sum256(block[:]))), size, charset))
+}
+
+// atDomain separates the keys used by At from other uses of SHA-256.
+const atDomain = "strand-at-v1"
+
+// SeededChoice returns a deterministically chosen element of items.
+//
+// Parameters:
+//   - items: the elements to choose from. Cannot be empty.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Returns the chosen element, or ErrEmptyItems if items is empty.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Choice() instead.
+func SeededChoice[T any](items []T, seed ...int64) (T, error) {
+	return choose(seededSource{rng: newSeededRand(seed...)}, items)
+}
+
+// SeededSample returns k distinct elements of items, deterministically chosen
+// without replacement. items is not modified.
+//
+// Parameters:
+//   - items: the elements to choose from.
+//   - k: the number of elements to return, from 0 up to len(items).
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Returns the chosen elements, or ErrInvalidSize if k is out of range.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Sample() instead.
+func SeededSample[T any](items []T, k int, seed ...int64) ([]T, error) {
+	return sample(seededSource{rng: newSeededRand(seed...)}, items, k)
+}
+
+// SeededShuffle deterministically permutes items in place with the Fisher-Yates
+// algorithm.
+//
+// Parameters:
+//   - items: the elements to permute.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Shuffle() instead.
+func SeededShuffle[T any](items []T, seed ...int64) {
+	// The seeded source never fails.
+	_ = shuffle(seededSource{rng: newSeededRand(seed...)}, items)
+}
+
+// SeededWeightedChoice returns an element of items deterministically chosen
+// with probability proportional to its weight.
+//
+// Parameters:
+//   - items: the elements to choose from. Cannot be empty.
+//   - weights: the relative weight of each element, in the same order as items.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Returns the chosen element, ErrEmptyItems if items is empty, or
+// ErrInvalidWeight if the weights are invalid or do not match items.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use WeightedChoice() instead.
+func SeededWeightedChoice[T any](items []T, weights []float64, seed ...int64) (T, error) {
+	return weightedChoose(seededSource{rng: newSeededRand(seed...)}, items, weights)
+}
+
+// newSeededRand creates the local random number generator shared by every seeded
+// function, so that the same seed always yields the same sequence.
+//
+// If seed is omitted, time.Now().UnixNano() is used as the default seed.
+func newSeededRand(seed ...int64) *rand.Rand {
+	return NewSeededGenerator(seed...).rng
+}
+
+// seededSource adapts a math/rand/v2 generator to the source interface used by
+// generators that share their selection logic with the crypto path.
+type seededSource struct {
+	rng *rand.Rand
+}
+
+// intN returns a pseudo-random value in [0, n). It never fails.
+func (s seededSource) intN(n int) (int, error) {
+	return s.rng.IntN(n), nil
+}
+
+// float64 returns a pseudo-random value in [0.0, 1.0). It never fails.
+func (s seededSource) float64() (float64, error) {
+	return s.rng.Float64(), nil
+}
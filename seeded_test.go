@@ -2,6 +2,8 @@ package strand_test
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -279,3 +281,193 @@ func TestSeededDeterminism(t *testing.T) {
 		assert.Equal(t, result1, result2)
 	})
 }
+
+// TestSeededGenerator verifies that a SeededGenerator continues its sequence
+// across calls and starts like SeededBytes.
+func TestSeededGenerator(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewSeededGenerator(42)
+
+	first := gen.String(16, strand.AlphaNumeric)
+	second := gen.String(16, strand.AlphaNumeric)
+
+	assert.Equal(t, strand.SeededString(16, strand.AlphaNumeric, 42), first)
+	assert.NotEqual(t, first, second, "Successive calls should continue the sequence")
+
+	again := strand.NewSeededGenerator(42)
+	assert.Equal(t, first, again.String(16, strand.AlphaNumeric))
+	assert.Equal(t, second, string(again.Bytes(16, strand.AlphaNumeric)))
+
+	assert.Empty(t, gen.Bytes(0, strand.AlphaNumeric))
+	assert.Equal(t, make([]byte, 4), gen.Bytes(4, ""))
+
+	phrases := strand.NewSeededGenerator(42)
+
+	phrase, err := phrases.Passphrase(4, "-")
+	require.NoError(t, err)
+
+	want, err := strand.SeededPassphrase(4, "-", 42)
+	require.NoError(t, err)
+	assert.Equal(t, want, phrase)
+
+	next, err := phrases.Passphrase(4, "-")
+	require.NoError(t, err)
+	assert.NotEqual(t, phrase, next, "Successive calls should continue the sequence")
+
+	_, err = phrases.Passphrase(0, "-")
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+}
+
+// TestSeededGeneratorCheckpoint verifies that a restored state resumes the
+// exact same sequence.
+func TestSeededGeneratorCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	gen := strand.NewSeededGenerator(7)
+	gen.Bytes(1000, strand.ALL)
+
+	state, err := gen.MarshalBinary()
+	require.NoError(t, err)
+
+	want := []string{gen.String(32, strand.ALL), gen.String(8, strand.Numbers), gen.String(64, strand.Symbols)}
+
+	var resumed strand.SeededGenerator
+	assert.Panics(t, func() { resumed.Bytes(8, strand.Numbers) }, "The zero value has no state")
+	require.NoError(t, resumed.UnmarshalBinary(state))
+
+	got := []string{resumed.String(32, strand.ALL), resumed.String(8, strand.Numbers), resumed.String(64, strand.Symbols)}
+	assert.Equal(t, want, got)
+
+	require.Error(t, resumed.UnmarshalBinary([]byte("not a state")))
+}
+
+// TestAt verifies that random-access strings are pinned, independent and
+// consistent with the charset.
+func TestAt(t *testing.T) {
+	t.Parallel()
+
+	// Pinned so that sharded fixtures stay identical across releases and machines.
+	assert.Equal(t, "PjQGqejLmuR1CvO7", strand.At(42, 0, 16, strand.AlphaNumeric))
+	assert.Equal(t, "t4iIy2XydLkAbZb8", strand.At(42, 1_000_000, 16, strand.AlphaNumeric))
+
+	seen := make(map[string]bool)
+	for index := range uint64(1000) {
+		value := strand.At(7, index, 12, strand.ALL)
+		assert.Len(t, value, 12)
+		assert.True(t, onlyContains(value, strand.ALL))
+		assert.False(t, seen[value], "index %d repeats an earlier string", index)
+
+		seen[value] = true
+	}
+
+	assert.NotEqual(t, strand.At(1, 5, 16, strand.ALL), strand.At(2, 5, 16, strand.ALL))
+	assert.Equal(t, strand.At(-3, 5, 16, strand.ALL), strand.At(-3, 5, 16, strand.ALL))
+	assert.Empty(t, strand.At(1, 1, 0, strand.ALL))
+}
+
+// TestSharedSeededGenerator verifies that concurrent callers together draw
+// exactly the sequence of a SeededGenerator with the same seed. Run with -race.
+func TestSharedSeededGenerator(t *testing.T) {
+	t.Parallel()
+
+	const (
+		workers = 8
+		draws   = 500
+	)
+
+	shared := strand.NewSharedSeededGenerator(42)
+	results := make([][]string, workers)
+
+	var wg sync.WaitGroup
+
+	for w := range workers {
+		wg.Go(func() {
+			for range draws {
+				results[w] = append(results[w], shared.String(16, strand.AlphaNumeric))
+			}
+		})
+	}
+
+	wg.Wait()
+
+	got := slices.Concat(results...)
+	slices.Sort(got)
+
+	sequential := strand.NewSeededGenerator(42)
+	want := make([]string, 0, workers*draws)
+
+	for range workers * draws {
+		want = append(want, sequential.String(16, strand.AlphaNumeric))
+	}
+
+	slices.Sort(want)
+	assert.Equal(t, want, got)
+}
+
+// TestSharedSeededGeneratorRandom verifies the Random methods and checkpointing
+// of a SharedSeededGenerator.
+func TestSharedSeededGeneratorRandom(t *testing.T) {
+	t.Parallel()
+
+	var r strand.Random = strand.NewSharedSeededGenerator(1)
+
+	shared, ok := r.(*strand.SharedSeededGenerator)
+	require.True(t, ok)
+
+	state, err := shared.MarshalBinary()
+	require.NoError(t, err)
+
+	draw := func(r strand.Random) []any {
+		u, _ := r.Uint64()
+		n, _ := r.IntN(10)
+		i, _ := r.IntRange(-5, 5)
+		f, _ := r.Float64()
+		d, _ := r.Duration(0, time.Second)
+
+		return []any{u, n, i, f, d}
+	}
+
+	first := draw(shared)
+
+	var resumed strand.SharedSeededGenerator
+	assert.Panics(t, func() { _, _ = resumed.Uint64() }, "The zero value has no state")
+	require.NoError(t, resumed.UnmarshalBinary(state))
+	assert.Equal(t, first, draw(&resumed))
+	assert.Equal(t, shared.Bytes(8, strand.Numbers), resumed.Bytes(8, strand.Numbers))
+
+	require.Error(t, resumed.UnmarshalBinary(nil))
+}
+
+// TestSeededSubstreams verifies that substreams are deterministic, independent
+// of each other and unaffected by scheduling. Run with -race.
+func TestSeededSubstreams(t *testing.T) {
+	t.Parallel()
+
+	const workers = 8
+
+	concurrent := make([]string, workers)
+
+	var wg sync.WaitGroup
+
+	for w := range workers {
+		wg.Go(func() {
+			concurrent[w] = strand.NewSeededSubstream(42, uint64(w)).String(32, strand.ALL)
+		})
+	}
+
+	wg.Wait()
+
+	for w := range workers {
+		assert.Equal(t, strand.NewSeededSubstream(42, uint64(w)).String(32, strand.ALL), concurrent[w])
+	}
+
+	sorted := slices.Clone(concurrent)
+	slices.Sort(sorted)
+	assert.Len(t, slices.Compact(sorted), workers, "streams should differ")
+
+	assert.NotEqual(t, strand.NewSeededSubstream(42, 0).String(32, strand.ALL), strand.NewSeededSubstream(43, 0).String(32, strand.ALL))
+
+	// Pinned so that sharded output stays identical across releases.
+	assert.Equal(t, "95469121", strand.NewSeededSubstream(7, 3).String(8, strand.Numbers))
+}
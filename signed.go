@@ -0,0 +1,297 @@
+package strand
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Errors returned by Signer.
+var (
+	ErrInvalidSigningKey = errors.New("invalid signing key")
+	ErrInvalidExpiry     = errors.New("invalid token expiry")
+	ErrTokenMalformed    = errors.New("token is malformed")
+	ErrUnknownSigningKey = errors.New("token is signed with an unknown key")
+	ErrTokenForged       = errors.New("token signature is invalid")
+	ErrTokenExpired      = errors.New("token has expired")
+)
+
+const (
+	// MinSigningKeySize is the minimum length in bytes of a signing key, which
+	// matches the output size of HMAC-SHA256.
+	MinSigningKeySize = 32
+
+	// MaxSigningKeyIDLength is the maximum length of a signing key ID.
+	MaxSigningKeyIDLength = 16
+
+	// signedNonceSize is the number of random bytes in every signed token.
+	signedNonceSize = 16
+
+	// signedVersion is the version byte of the current token layout.
+	signedVersion = 1
+
+	// signedPayloadSize is the length of the encoded payload: version, expiry,
+	// nonce and MAC.
+	signedPayloadSize = 1 + 8 + signedNonceSize + sha256.Size
+
+	// signedSeparator separates the key ID from the payload.
+	signedSeparator = "."
+
+	// signedDomain is mixed into every MAC so that keys shared with other uses
+	// of HMAC-SHA256 cannot produce valid tokens.
+	signedDomain = "strand-signed-token"
+)
+
+// SigningKey is a key used to sign tokens, together with the ID that is
+// embedded in every token it signs.
+type SigningKey struct {
+	// ID identifies the key. It consists of 1 to MaxSigningKeyIDLength letters,
+	// digits, hyphens or underscores, and is visible in the token.
+	ID string
+
+	// Key is the HMAC-SHA256 key. It must be at least MinSigningKeySize bytes of
+	// uniformly random data, such as bytes from SecretKey or crypto/rand.
+	Key []byte
+}
+
+// SignerOptions controls the format of tokens created by a Signer.
+type SignerOptions struct {
+	// Alphabet is the charset the payload is encoded in. Defaults to
+	// Base62Alphabet, which is safe in URLs, file names and email links. It must
+	// not contain ".".
+	Alphabet string
+
+	// Generator is the source of the random nonces. Defaults to crypto/rand.
+	Generator *Generator
+}
+
+// Claims describes a verified token.
+type Claims struct {
+	// KeyID is the ID of the key the token was signed with.
+	KeyID string
+
+	// Expiry is the time, truncated to the second, at which the token expires.
+	Expiry time.Time
+
+	// Nonce is the random part of the token. It can be recorded to make tokens
+	// single-use.
+	Nonce []byte
+}
+
+// Signer creates and verifies self-validating tokens for purposes such as email
+// verification and magic links, where a token must expire and resist tampering
+// without a database lookup.
+//
+// A token has the form "<key ID>.<payload>". The payload encodes a random nonce
+// and the expiry time, authenticated with HMAC-SHA256 together with the key ID
+// and a purpose label. The purpose is not stored in the token, so a token issued
+// for one purpose fails verification as forged for any other.
+//
+// Keys are rotated by creating a Signer whose first key is the new key and whose
+// remaining keys are the old ones: new tokens are signed with the first key, and
+// tokens signed with any of the keys keep verifying until the old keys are
+// removed.
+//
+// Signed tokens are not encrypted; the key ID and expiry can be read by anyone
+// holding the token.
+//
+// A Signer is immutable and safe for concurrent use.
+type Signer struct {
+	current   string
+	keys      map[string][]byte
+	codec     *BaseN
+	generator *Generator
+}
+
+// NewSigner creates a Signer.
+//
+// Parameters:
+//   - keys: the signing keys. The first key signs new tokens, and every key
+//     verifies tokens. Must contain at least one key, and IDs must be unique.
+//   - opts: the format of the tokens.
+//
+// Returns:
+//   - *Signer: the signer.
+//   - error: ErrInvalidSigningKey if no keys are given or a key is invalid, or
+//     ErrInvalidAlphabet if the alphabet is invalid.
+func NewSigner(keys []SigningKey, opts SignerOptions) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: at least one key is required", ErrInvalidSigningKey)
+	}
+
+	if opts.Alphabet == "" {
+		opts.Alphabet = Base62Alphabet
+	}
+
+	if strings.Contains(opts.Alphabet, signedSeparator) {
+		return nil, fmt.Errorf("%w: cannot contain %q", ErrInvalidAlphabet, signedSeparator)
+	}
+
+	codec, err := NewBaseN(opts.Alphabet)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Generator == nil {
+		opts.Generator = defaultGenerator()
+	}
+
+	s := &Signer{
+		current:   keys[0].ID,
+		keys:      make(map[string][]byte, len(keys)),
+		codec:     codec,
+		generator: opts.Generator,
+	}
+
+	for _, key := range keys {
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+
+		if _, ok := s.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate ID %q", ErrInvalidSigningKey, key.ID)
+		}
+
+		s.keys[key.ID] = append([]byte(nil), key.Key...)
+	}
+
+	return s, nil
+}
+
+// validate reports whether the key can be used to sign tokens.
+func (k SigningKey) validate() error {
+	if k.ID == "" || len(k.ID) > MaxSigningKeyIDLength {
+		return fmt.Errorf("%w: ID must be 1 to %d characters", ErrInvalidSigningKey, MaxSigningKeyIDLength)
+	}
+
+	for i := range len(k.ID) {
+		if !strings.ContainsRune(AlphaNumeric+"-_", rune(k.ID[i])) {
+			return fmt.Errorf("%w: ID %q contains %q", ErrInvalidSigningKey, k.ID, k.ID[i])
+		}
+	}
+
+	if len(k.Key) < MinSigningKeySize {
+		return fmt.Errorf("%w: key %q must be at least %d bytes", ErrInvalidSigningKey, k.ID, MinSigningKeySize)
+	}
+
+	return nil
+}
+
+// Sign creates a token for purpose that expires ttl from now.
+//
+// Parameters:
+//   - purpose: a label such as "email-verification" that the token is bound
+//     to. May be empty.
+//   - ttl: how long the token remains valid. Must be greater than 0.
+//
+// Returns:
+//   - string: the token.
+//   - error: ErrInvalidExpiry if ttl is invalid, or an error if random
+//     generation fails.
+func (s *Signer) Sign(purpose string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidExpiry)
+	}
+
+	return s.SignWithExpiry(purpose, time.Now().Add(ttl))
+}
+
+// SignWithExpiry creates a token for purpose that expires at the given time,
+// truncated to the second. Expiry times in the past are allowed, which is
+// useful in tests.
+//
+// Returns ErrInvalidExpiry if expiry is before the Unix epoch, or an error if
+// random generation fails.
+func (s *Signer) SignWithExpiry(purpose string, expiry time.Time) (string, error) {
+	if expiry.Unix() < 0 {
+		return "", fmt.Errorf("%w: must not be before the Unix epoch", ErrInvalidExpiry)
+	}
+
+	payload := make([]byte, signedPayloadSize)
+	payload[0] = signedVersion
+	binary.BigEndian.PutUint64(payload[1:9], uint64(expiry.Unix()))
+
+	nonce, err := s.generator.read(context.Background(), signedNonceSize)
+	if err != nil {
+		return "", err
+	}
+
+	copy(payload[9:], nonce)
+	copy(payload[9+signedNonceSize:], signedMAC(s.keys[s.current], s.current, purpose, payload[:9+signedNonceSize]))
+
+	return s.current + signedSeparator + s.codec.Encode(payload), nil
+}
+
+// Verify checks that token was signed by one of the Signer's keys for purpose
+// and has not expired.
+//
+// Returns:
+//   - Claims: the contents of the token.
+//   - error: ErrTokenMalformed if the token cannot be parsed,
+//     ErrUnknownSigningKey if its key is not known, ErrTokenForged if it was
+//     tampered with or issued for another purpose, or ErrTokenExpired if it has
+//     expired. The claims are also returned with ErrTokenExpired.
+func (s *Signer) Verify(token, purpose string) (Claims, error) {
+	return s.VerifyAt(token, purpose, time.Now())
+}
+
+// VerifyAt works like Verify but checks the expiry against now instead of the
+// current time.
+func (s *Signer) VerifyAt(token, purpose string, now time.Time) (Claims, error) {
+	id, encoded, ok := strings.Cut(token, signedSeparator)
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: missing key ID", ErrTokenMalformed)
+	}
+
+	key, ok := s.keys[id]
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: %q", ErrUnknownSigningKey, id)
+	}
+
+	payload, err := s.codec.Decode(encoded)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrTokenMalformed, err)
+	}
+
+	if len(payload) != signedPayloadSize || payload[0] != signedVersion {
+		return Claims{}, fmt.Errorf("%w: unsupported layout", ErrTokenMalformed)
+	}
+
+	signed, mac := payload[:9+signedNonceSize], payload[9+signedNonceSize:]
+	if !hmac.Equal(mac, signedMAC(key, id, purpose, signed)) {
+		return Claims{}, ErrTokenForged
+	}
+
+	claims := Claims{
+		KeyID:  id,
+		Expiry: time.Unix(int64(binary.BigEndian.Uint64(payload[1:9])), 0),
+		Nonce:  payload[9 : 9+signedNonceSize],
+	}
+
+	if !now.Before(claims.Expiry) {
+		return claims, fmt.Errorf("%w: at %v", ErrTokenExpired, claims.Expiry)
+	}
+
+	return claims, nil
+}
+
+// signedMAC authenticates the signed part of a payload together with the key ID and
+// purpose. Every variable-length field is length-prefixed so that no two
+// inputs share an encoding.
+func signedMAC(key []byte, id, purpose string, signed []byte) []byte {
+	h := hmac.New(sha256.New, key)
+
+	for _, field := range [][]byte{[]byte(signedDomain), []byte(id), []byte(purpose), signed} {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		h.Write(length[:])
+		h.Write(field)
+	}
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,173 @@
+package strand_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signingKey returns a valid key with the given ID, filled with fill.
+func signingKey(id string, fill byte) strand.SigningKey {
+	return strand.SigningKey{ID: id, Key: bytes.Repeat([]byte{fill}, strand.MinSigningKeySize)}
+}
+
+// TestSigner verifies that tokens verify for their purpose until they expire.
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	signer, err := strand.NewSigner([]strand.SigningKey{signingKey("k1", 1)}, strand.SignerOptions{})
+	require.NoError(t, err)
+
+	issued := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiry := issued.Add(time.Hour)
+
+	token, err := signer.SignWithExpiry("email-verification", expiry)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "k1."))
+	assert.True(t, onlyContains(strings.TrimPrefix(token, "k1."), strand.Base62Alphabet))
+
+	claims, err := signer.VerifyAt(token, "email-verification", issued)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", claims.KeyID)
+	assert.True(t, expiry.Equal(claims.Expiry))
+	assert.Len(t, claims.Nonce, 16)
+
+	claims, err = signer.VerifyAt(token, "email-verification", expiry)
+	require.ErrorIs(t, err, strand.ErrTokenExpired)
+	assert.True(t, expiry.Equal(claims.Expiry))
+
+	_, err = signer.VerifyAt(token, "password-reset", issued)
+	require.ErrorIs(t, err, strand.ErrTokenForged)
+
+	other, err := signer.SignWithExpiry("email-verification", expiry)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other, "Nonces should make every token unique")
+
+	live, err := signer.Sign("", time.Minute)
+	require.NoError(t, err)
+
+	_, err = signer.Verify(live, "")
+	require.NoError(t, err)
+}
+
+// TestSignerRotation verifies that tokens signed with an old key keep verifying
+// until the key is removed.
+func TestSignerRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	before, err := strand.NewSigner([]strand.SigningKey{signingKey("2029", 1)}, strand.SignerOptions{})
+	require.NoError(t, err)
+
+	oldToken, err := before.SignWithExpiry("login", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	during, err := strand.NewSigner([]strand.SigningKey{signingKey("2030", 2), signingKey("2029", 1)}, strand.SignerOptions{})
+	require.NoError(t, err)
+
+	newToken, err := during.SignWithExpiry("login", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(newToken, "2030."))
+
+	claims, err := during.VerifyAt(oldToken, "login", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2029", claims.KeyID)
+
+	after, err := strand.NewSigner([]strand.SigningKey{signingKey("2030", 2)}, strand.SignerOptions{})
+	require.NoError(t, err)
+
+	_, err = after.VerifyAt(oldToken, "login", now)
+	require.ErrorIs(t, err, strand.ErrUnknownSigningKey)
+
+	_, err = after.VerifyAt(newToken, "login", now)
+	require.NoError(t, err)
+
+	// A token relabeled with another known key ID must not verify.
+	_, err = during.VerifyAt("2030"+strings.TrimPrefix(oldToken, "2029"), "login", now)
+	require.ErrorIs(t, err, strand.ErrTokenForged)
+}
+
+// TestSignerTampering verifies that modified and malformed tokens are rejected.
+func TestSignerTampering(t *testing.T) {
+	t.Parallel()
+
+	signer, err := strand.NewSigner([]strand.SigningKey{signingKey("k", 7)}, strand.SignerOptions{Alphabet: strand.Base58Alphabet})
+	require.NoError(t, err)
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	token, err := signer.SignWithExpiry("purpose", now.Add(time.Minute))
+	require.NoError(t, err)
+
+	payload := []byte(token)
+	last := len(payload) - 1
+	payload[last] = strand.Base58Alphabet[(strings.IndexByte(strand.Base58Alphabet, payload[last])+1)%58]
+
+	_, err = signer.VerifyAt(string(payload), "purpose", now)
+	require.ErrorIs(t, err, strand.ErrTokenForged)
+
+	tests := []struct {
+		name  string // Description of the test case
+		token string // Malformed token
+	}{
+		{name: "empty", token: ""},
+		{name: "no separator", token: strings.ReplaceAll(token, ".", "")},
+		{name: "invalid character", token: token + "0"},
+		{name: "truncated", token: token[:len(token)-5]},
+		{name: "extended", token: token + "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := signer.VerifyAt(tt.token, "purpose", now)
+			require.ErrorIs(t, err, strand.ErrTokenMalformed)
+		})
+	}
+}
+
+// TestNewSignerErrors verifies that invalid keys and options are rejected.
+func TestNewSignerErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string               // Description of the test case
+		keys []strand.SigningKey  // Keys under test
+		opts strand.SignerOptions // Options under test
+		want error                // Expected error
+	}{
+		{name: "no keys", keys: nil, want: strand.ErrInvalidSigningKey},
+		{name: "short key", keys: []strand.SigningKey{{ID: "k", Key: []byte("short")}}, want: strand.ErrInvalidSigningKey},
+		{name: "empty ID", keys: []strand.SigningKey{signingKey("", 1)}, want: strand.ErrInvalidSigningKey},
+		{name: "ID with separator", keys: []strand.SigningKey{signingKey("a.b", 1)}, want: strand.ErrInvalidSigningKey},
+		{name: "long ID", keys: []strand.SigningKey{signingKey(strings.Repeat("k", 17), 1)}, want: strand.ErrInvalidSigningKey},
+		{name: "duplicate ID", keys: []strand.SigningKey{signingKey("k", 1), signingKey("k", 2)}, want: strand.ErrInvalidSigningKey},
+		{name: "alphabet with separator", keys: []strand.SigningKey{signingKey("k", 1)}, opts: strand.SignerOptions{Alphabet: "ab."}, want: strand.ErrInvalidAlphabet},
+		{name: "invalid alphabet", keys: []strand.SigningKey{signingKey("k", 1)}, opts: strand.SignerOptions{Alphabet: "aa"}, want: strand.ErrInvalidAlphabet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := strand.NewSigner(tt.keys, tt.opts)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	signer, err := strand.NewSigner([]strand.SigningKey{signingKey("k", 1)}, strand.SignerOptions{})
+	require.NoError(t, err)
+
+	_, err = signer.Sign("purpose", 0)
+	require.ErrorIs(t, err, strand.ErrInvalidExpiry)
+
+	_, err = signer.SignWithExpiry("purpose", time.Unix(-1, 0))
+	require.ErrorIs(t, err, strand.ErrInvalidExpiry)
+}
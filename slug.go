@@ -0,0 +1,220 @@
+package strand
+
+import (
+	"context"
+	_ "embed" // Required for the embedded word lists.
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSlugOptions is returned when SlugOptions cannot produce a valid slug.
+var ErrInvalidSlugOptions = errors.New("invalid slug options")
+
+const (
+	// DefaultSlugWords is the number of words in a slug when SlugOptions.Words is zero.
+	DefaultSlugWords = 2
+
+	// maxDNSLabel is the maximum length of a DNS label per RFC 1035.
+	maxDNSLabel = 63
+
+	// dnsLabelCharset contains the characters allowed in a DNS label besides the hyphen.
+	dnsLabelCharset = LowercaseAlphabet + Numbers
+)
+
+//go:embed wordlists/adjectives.txt
+var adjectiveList string
+
+//go:embed wordlists/nouns.txt
+var nounList string
+
+// The embedded word lists, split once on first use. Callers must not modify them.
+var (
+	adjectives = sync.OnceValue(func() []string { return strings.Fields(adjectiveList) }) //nolint:gochecknoglobals // Read-only after the first call.
+	nouns      = sync.OnceValue(func() []string { return strings.Fields(nounList) })      //nolint:gochecknoglobals // Read-only after the first call.
+)
+
+// SlugOptions controls the shape of human-readable names generated by Slug.
+// The zero value produces names like "brave-otter".
+type SlugOptions struct {
+	// Words is the number of words. The last word is a noun and the others are
+	// adjectives. Defaults to DefaultSlugWords.
+	Words int
+
+	// Separator is placed between words and before the suffix. Defaults to "-".
+	Separator string
+
+	// SuffixLength is the number of suffix characters appended after the words.
+	// Zero disables the suffix.
+	SuffixLength int
+
+	// SuffixCharset is the charset the suffix is drawn from. Defaults to Numbers.
+	SuffixCharset string
+
+	// DNSSafe restricts the result to a valid DNS label: lowercase letters,
+	// digits and hyphens, at most 63 characters. It requires the separator to be
+	// "-" and the suffix charset to contain only lowercase letters and digits.
+	DNSSafe bool
+}
+
+// Entropy reports the number of bits of entropy in a slug generated with these
+// options, assuming the suffix charset has no duplicate characters.
+//
+// Returns an error if the options are invalid.
+func (o SlugOptions) Entropy() (float64, error) {
+	o, err := o.normalize()
+	if err != nil {
+		return 0, err
+	}
+
+	bits := float64(o.Words-1)*math.Log2(float64(len(adjectives()))) + math.Log2(float64(len(nouns())))
+	if o.SuffixLength > 0 {
+		bits += float64(o.SuffixLength) * math.Log2(float64(len(o.SuffixCharset)))
+	}
+
+	return bits, nil
+}
+
+// normalize fills in defaults and validates the options.
+func (o SlugOptions) normalize() (SlugOptions, error) {
+	if o.Words == 0 {
+		o.Words = DefaultSlugWords
+	}
+
+	if o.Separator == "" {
+		o.Separator = "-"
+	}
+
+	if o.SuffixCharset == "" {
+		o.SuffixCharset = Numbers
+	}
+
+	if o.Words < 1 {
+		return o, fmt.Errorf("%w: words must be greater than 0", ErrInvalidSlugOptions)
+	}
+
+	if o.SuffixLength < 0 {
+		return o, fmt.Errorf("%w: suffix length cannot be negative", ErrInvalidSlugOptions)
+	}
+
+	if !o.DNSSafe {
+		return o, nil
+	}
+
+	if o.Separator != "-" {
+		return o, fmt.Errorf("%w: DNS-safe slugs must use the \"-\" separator", ErrInvalidSlugOptions)
+	}
+
+	for i := range len(o.SuffixCharset) {
+		if strings.IndexByte(dnsLabelCharset, o.SuffixCharset[i]) < 0 {
+			return o, fmt.Errorf("%w: suffix charset is not DNS-safe", ErrInvalidSlugOptions)
+		}
+	}
+
+	if o.maxLen() > maxDNSLabel {
+		return o, fmt.Errorf("%w: slug may exceed %d characters", ErrInvalidSlugOptions, maxDNSLabel)
+	}
+
+	return o, nil
+}
+
+// maxLen returns the longest slug the options can produce.
+func (o SlugOptions) maxLen() int {
+	longest := func(words []string) int {
+		n := 0
+		for _, w := range words {
+			n = max(n, len(w))
+		}
+
+		return n
+	}
+
+	n := (o.Words-1)*(longest(adjectives())+len(o.Separator)) + longest(nouns())
+	if o.SuffixLength > 0 {
+		n += len(o.Separator) + o.SuffixLength
+	}
+
+	return n
+}
+
+// Slug generates a human-readable name from embedded adjective and noun lists,
+// such as "brave-otter" or "lucid-falcon-4821", using a cryptographically
+// secure source.
+//
+// Slugs are meant for naming things, such as ephemeral environments and preview
+// deployments, where readability matters more than secrecy. Use
+// SlugOptions.Entropy to check whether a configuration is unique enough.
+//
+// Parameters:
+//   - opts: the shape of the slug.
+//
+// Returns:
+//   - string: the generated slug.
+//   - error: an error if random generation fails or if the options are invalid.
+func Slug(opts SlugOptions) (string, error) {
+	return SlugWithContext(context.Background(), opts)
+}
+
+// SlugWithContext works like Slug but accepts a context for cancellation support.
+func SlugWithContext(ctx context.Context, opts SlugOptions) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to generate slug due to context ending early: %w", ctx.Err())
+	default:
+		return generateSlug(newCryptoSource(), opts)
+	}
+}
+
+// SeededSlug generates a deterministic human-readable name.
+//
+// Parameters:
+//   - opts: the shape of the slug.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Slug() instead.
+func SeededSlug(opts SlugOptions, seed ...int64) (string, error) {
+	return generateSlug(seededSource{rng: newSeededRand(seed...)}, opts)
+}
+
+// generateSlug draws the words and suffix of a slug from src.
+func generateSlug(src source, opts SlugOptions) (string, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, opts.Words+1)
+	for i := range opts.Words {
+		words := adjectives()
+		if i == opts.Words-1 {
+			words = nouns()
+		}
+
+		n, err := src.intN(len(words))
+		if err != nil {
+			return "", err
+		}
+
+		parts = append(parts, words[n])
+	}
+
+	if opts.SuffixLength > 0 {
+		suffix := make([]byte, opts.SuffixLength)
+		for i := range suffix {
+			n, err := src.intN(len(opts.SuffixCharset))
+			if err != nil {
+				return "", err
+			}
+
+			suffix[i] = opts.SuffixCharset[n]
+		}
+
+		parts = append(parts, string(suffix))
+	}
+
+	return strings.Join(parts, opts.Separator), nil
+}
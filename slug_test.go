@@ -0,0 +1,140 @@
+package strand_test
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSlug verifies the shape of generated slugs for a range of options.
+func TestSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string             // Description of the test case
+		opts strand.SlugOptions // Options under test
+		want *regexp.Regexp     // Expected shape
+	}{
+		{
+			name: "defaults",
+			opts: strand.SlugOptions{},
+			want: regexp.MustCompile(`^[a-z]+-[a-z]+$`),
+		},
+		{
+			name: "three words with underscore",
+			opts: strand.SlugOptions{Words: 3, Separator: "_"},
+			want: regexp.MustCompile(`^[a-z]+_[a-z]+_[a-z]+$`),
+		},
+		{
+			name: "numeric suffix",
+			opts: strand.SlugOptions{SuffixLength: 4},
+			want: regexp.MustCompile(`^[a-z]+-[a-z]+-\d{4}$`),
+		},
+		{
+			name: "random suffix from charset",
+			opts: strand.SlugOptions{SuffixLength: 6, SuffixCharset: strand.UppercaseAlphabet},
+			want: regexp.MustCompile(`^[a-z]+-[a-z]+-[A-Z]{6}$`),
+		},
+		{
+			name: "single word",
+			opts: strand.SlugOptions{Words: 1},
+			want: regexp.MustCompile(`^[a-z]+$`),
+		},
+		{
+			name: "DNS safe",
+			opts: strand.SlugOptions{Words: 3, SuffixLength: 5, SuffixCharset: strand.LowercaseAlphabet + strand.Numbers, DNSSafe: true},
+			want: regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for range 50 {
+				slug, err := strand.Slug(tt.opts)
+				require.NoError(t, err)
+				assert.Regexp(t, tt.want, slug)
+			}
+
+			seeded, err := strand.SeededSlug(tt.opts, 42)
+			require.NoError(t, err)
+			assert.Regexp(t, tt.want, seeded)
+
+			again, err := strand.SeededSlug(tt.opts, 42)
+			require.NoError(t, err)
+			assert.Equal(t, seeded, again, "Same seed should produce same output")
+		})
+	}
+}
+
+// TestSlugEntropy verifies the reported entropy.
+func TestSlugEntropy(t *testing.T) {
+	t.Parallel()
+
+	words, err := strand.SlugOptions{}.Entropy()
+	require.NoError(t, err)
+	assert.Greater(t, words, 13.0)
+
+	withSuffix, err := strand.SlugOptions{SuffixLength: 4}.Entropy()
+	require.NoError(t, err)
+	assert.InDelta(t, words+4*math.Log2(10), withSuffix, 1e-9)
+
+	moreWords, err := strand.SlugOptions{Words: 3}.Entropy()
+	require.NoError(t, err)
+	assert.Greater(t, moreWords, words)
+
+	_, err = strand.SlugOptions{Words: -1}.Entropy()
+	require.ErrorIs(t, err, strand.ErrInvalidSlugOptions)
+}
+
+// TestSlugErrors verifies that invalid options are rejected.
+func TestSlugErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string             // Description of the test case
+		opts strand.SlugOptions // Invalid options
+	}{
+		{name: "negative words", opts: strand.SlugOptions{Words: -2}},
+		{name: "negative suffix", opts: strand.SlugOptions{SuffixLength: -1}},
+		{name: "DNS safe with underscore", opts: strand.SlugOptions{Separator: "_", DNSSafe: true}},
+		{name: "DNS safe with uppercase suffix", opts: strand.SlugOptions{SuffixLength: 4, SuffixCharset: strand.Alphabet, DNSSafe: true}},
+		{name: "DNS safe too long", opts: strand.SlugOptions{Words: 4, SuffixLength: 40, DNSSafe: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := strand.Slug(tt.opts)
+			require.ErrorIs(t, err, strand.ErrInvalidSlugOptions)
+
+			_, err = strand.SeededSlug(tt.opts, 1)
+			require.ErrorIs(t, err, strand.ErrInvalidSlugOptions)
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := strand.SlugWithContext(ctx, strand.SlugOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestSlugWordsAreDNSSafe verifies that every embedded word is usable in a DNS label.
+func TestSlugWordsAreDNSSafe(t *testing.T) {
+	t.Parallel()
+
+	for seed := range int64(500) {
+		slug, err := strand.SeededSlug(strand.SlugOptions{Words: 2, DNSSafe: true}, seed)
+		require.NoError(t, err)
+		assert.True(t, onlyContains(strings.ReplaceAll(slug, "-", ""), strand.LowercaseAlphabet))
+	}
+}
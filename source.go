@@ -0,0 +1,87 @@
+package strand
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// source yields uniformly distributed indexes. It lets generators that pick
+// from several charsets share their selection logic between the crypto path
+// and the seeded path.
+type source interface {
+	// intN returns a value in [0, n). n must be greater than 0.
+	intN(n int) (int, error)
+
+	// float64 returns a value in [0.0, 1.0).
+	float64() (float64, error)
+}
+
+// cryptoSource draws indexes from crypto/rand using rejection sampling, so every
+// index is equally likely regardless of n.
+//
+// Random bytes are read in small blocks to avoid a system call per index.
+type cryptoSource struct {
+	reader io.Reader
+	buf    [64]byte
+	off    int
+}
+
+// newCryptoSource returns a source backed by crypto/rand.Reader.
+func newCryptoSource() *cryptoSource {
+	return defaultGenerator().source()
+}
+
+// source returns a source that draws from the Generator's reader.
+func (g *Generator) source() *cryptoSource {
+	s := &cryptoSource{reader: g.reader}
+	s.off = len(s.buf)
+
+	return s
+}
+
+// uint64 returns 64 uniformly random bits.
+func (s *cryptoSource) uint64() (uint64, error) {
+	if s.off+8 > len(s.buf) {
+		if _, err := io.ReadFull(s.reader, s.buf[:]); err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrRandomFailure, err)
+		}
+
+		s.off = 0
+	}
+
+	v := binary.LittleEndian.Uint64(s.buf[s.off:])
+	s.off += 8
+
+	return v, nil
+}
+
+// intN returns a uniformly random value in [0, n).
+func (s *cryptoSource) intN(n int) (int, error) {
+	bound := uint64(n)
+
+	// Values below threshold would make the low end of the range more likely,
+	// since 2^64 is not generally a multiple of n.
+	threshold := -bound % bound
+
+	for {
+		v, err := s.uint64()
+		if err != nil {
+			return 0, err
+		}
+
+		if v >= threshold {
+			return int(v % bound), nil
+		}
+	}
+}
+
+// float64 returns a uniformly random value in [0.0, 1.0) with 53 bits of precision.
+func (s *cryptoSource) float64() (float64, error) {
+	v, err := s.uint64()
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(v>>11) / (1 << 53), nil
+}
@@ -0,0 +1,41 @@
+package strand
+
+import "context"
+
+// Spec describes a random string by its length and charset, so that the shape
+// of a value can be configured once and passed to the functions that issue,
+// batch or prefetch values.
+type Spec struct {
+	// Size is the length of the string. Must be greater than 0.
+	Size int
+
+	// Charset is the string of characters the value is drawn from. Cannot be empty.
+	Charset string
+}
+
+// Generate generates a cryptographically secure random string of the shape
+// described by the spec.
+//
+// Returns an error if random generation fails or if the spec is invalid.
+func (s Spec) Generate() (string, error) {
+	return s.GenerateWithContext(context.Background())
+}
+
+// GenerateWithContext works like Generate but accepts a context for
+// cancellation support.
+func (s Spec) GenerateWithContext(ctx context.Context) (string, error) {
+	return StringWithContext(ctx, s.Size, s.Charset)
+}
+
+// validate reports whether the spec describes a string that can be generated.
+func (s Spec) validate() error {
+	if s.Size <= 0 {
+		return ErrInvalidSize
+	}
+
+	if len(s.Charset) == 0 {
+		return ErrEmptyCharset
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package strand_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSpec verifies generation and validation of specs.
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	value, err := strand.Spec{Size: 12, Charset: strand.Numbers}.Generate()
+	require.NoError(t, err)
+	assert.Len(t, value, 12)
+	assert.True(t, onlyContains(value, strand.Numbers))
+
+	_, err = strand.Spec{Charset: strand.Numbers}.Generate()
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	_, err = strand.Spec{Size: 12}.Generate()
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.Spec{Size: 12, Charset: strand.Numbers}.GenerateWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,81 @@
+package stats
+
+import "math"
+
+const (
+	gammaEpsilon    = 1e-15
+	gammaIterations = 1000
+)
+
+// upperIncompleteGamma returns the regularized upper incomplete gamma function
+// Q(a, x), which gives the p-value of a chi-squared statistic 2x with 2a degrees
+// of freedom.
+//
+// It uses the series expansion of P(a, x) below x = a+1 and Lentz's continued
+// fraction for Q(a, x) above, as described in Numerical Recipes.
+func upperIncompleteGamma(a, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 1
+	case x < a+1:
+		return 1 - lowerSeries(a, x)
+	default:
+		return upperFraction(a, x)
+	}
+}
+
+// lowerSeries computes P(a, x) by its power series.
+func lowerSeries(a, x float64) float64 {
+	lgamma, _ := math.Lgamma(a)
+
+	term := 1 / a
+	sum := term
+
+	for n := 1; n < gammaIterations; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+
+		if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+
+	return sum * math.Exp(-x+a*math.Log(x)-lgamma)
+}
+
+// upperFraction computes Q(a, x) by its continued fraction.
+func upperFraction(a, x float64) float64 {
+	const tiny = 1e-300
+
+	lgamma, _ := math.Lgamma(a)
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+
+	for n := 1; n < gammaIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-lgamma) * h
+}
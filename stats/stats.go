@@ -0,0 +1,282 @@
+// Package stats provides statistical tests for judging the quality of random
+// output: chi-squared goodness-of-fit, serial correlation, runs and monobit tests.
+//
+// Each test returns a Result carrying a p-value: the probability of a result at
+// least as extreme if the output were truly uniform and independent. A tiny
+// p-value is evidence of bias or correlation. The tests are cheap enough to run
+// from go test, for example:
+//
+//	sample, _ := strand.Bytes(100_000, strand.AlphaNumeric)
+//	result, _ := stats.ChiSquaredUniform(sample, strand.AlphaNumeric)
+//	if !result.Pass(1e-6) {
+//		t.Errorf("biased output: %v", result)
+//	}
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Errors returned by the statistical tests.
+var (
+	ErrInsufficientData = errors.New("insufficient data for a reliable test")
+	ErrOutOfCharset     = errors.New("sample contains a character outside the charset")
+	ErrLengthMismatch   = errors.New("observed and expected counts differ in length")
+)
+
+// Result is the outcome of a statistical test.
+type Result struct {
+	// Name identifies the test.
+	Name string
+
+	// Statistic is the test statistic, such as the chi-squared value or a z-score.
+	Statistic float64
+
+	// PValue is the probability of a statistic at least this extreme under the
+	// hypothesis that the data is uniform and independent.
+	PValue float64
+}
+
+// Pass reports whether the test fails to reject randomness at significance alpha,
+// that is whether PValue >= alpha.
+//
+// Choose alpha with the number of tests in mind: a suite of 100 tests at alpha
+// 0.01 is expected to see one failure by chance. Values around 1e-6 keep
+// non-deterministic suites from flaking while still catching real bias.
+func (r Result) Pass(alpha float64) bool {
+	return r.PValue >= alpha
+}
+
+// String formats the result for test failure messages.
+func (r Result) String() string {
+	return fmt.Sprintf("%s: statistic=%.4f p=%.3g", r.Name, r.Statistic, r.PValue)
+}
+
+// ChiSquared performs Pearson's chi-squared goodness-of-fit test of observed
+// counts against expected counts.
+//
+// Returns ErrInsufficientData if any expected count is below 5, where the
+// chi-squared approximation becomes unreliable, or if there are fewer than two
+// categories.
+func ChiSquared(observed []int, expected []float64) (Result, error) {
+	if len(observed) != len(expected) {
+		return Result{}, ErrLengthMismatch
+	}
+
+	if len(observed) < 2 {
+		return Result{}, fmt.Errorf("%w: need at least 2 categories", ErrInsufficientData)
+	}
+
+	statistic := 0.0
+
+	for i, want := range expected {
+		if want < 5 {
+			return Result{}, fmt.Errorf("%w: expected count %.2f below 5", ErrInsufficientData, want)
+		}
+
+		diff := float64(observed[i]) - want
+		statistic += diff * diff / want
+	}
+
+	return Result{
+		Name:      "chi-squared",
+		Statistic: statistic,
+		PValue:    ChiSquaredPValue(statistic, len(observed)-1),
+	}, nil
+}
+
+// ChiSquaredPValue returns the probability that a chi-squared distributed
+// variable with the given degrees of freedom is at least statistic.
+func ChiSquaredPValue(statistic float64, degrees int) float64 {
+	return upperIncompleteGamma(float64(degrees)/2, statistic/2)
+}
+
+// ChiSquaredUniform tests whether the characters of sample are drawn uniformly
+// from charset. A character listed twice in charset is expected twice as often.
+func ChiSquaredUniform(sample []byte, charset string) (Result, error) {
+	if charset == "" {
+		return Result{}, fmt.Errorf("%w: empty charset", ErrInsufficientData)
+	}
+
+	var (
+		category [256]int
+		weights  []int
+	)
+
+	for i := range len(charset) {
+		c := charset[i]
+		if category[c] == 0 {
+			weights = append(weights, 0)
+			category[c] = len(weights)
+		}
+
+		weights[category[c]-1]++
+	}
+
+	observed := make([]int, len(weights))
+
+	for i, c := range sample {
+		if category[c] == 0 {
+			return Result{}, fmt.Errorf("%w: %q at position %d", ErrOutOfCharset, c, i)
+		}
+
+		observed[category[c]-1]++
+	}
+
+	expected := make([]float64, len(weights))
+	for i, weight := range weights {
+		expected[i] = float64(len(sample)) * float64(weight) / float64(len(charset))
+	}
+
+	return ChiSquared(observed, expected)
+}
+
+// Indexes maps every character of sample to its first position in charset,
+// turning generated output into numeric values for SerialCorrelation and Runs.
+func Indexes(sample []byte, charset string) ([]float64, error) {
+	values := make([]float64, len(sample))
+
+	for i, c := range sample {
+		index := strings.IndexByte(charset, c)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrOutOfCharset, c, i)
+		}
+
+		values[i] = float64(index)
+	}
+
+	return values, nil
+}
+
+// SerialCorrelation measures the lag-1 correlation between consecutive values.
+// For independent values the coefficient is close to zero and, scaled by
+// sqrt(n), approximately standard normal.
+func SerialCorrelation(values []float64) (Result, error) {
+	n := len(values)
+	if n < 30 {
+		return Result{}, fmt.Errorf("%w: need at least 30 values", ErrInsufficientData)
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+
+	mean /= float64(n)
+
+	var numerator, denominator float64
+
+	for i, v := range values {
+		next := values[(i+1)%n]
+		numerator += (v - mean) * (next - mean)
+		denominator += (v - mean) * (v - mean)
+	}
+
+	if denominator == 0 {
+		return Result{Name: "serial correlation", Statistic: 1, PValue: 0}, nil
+	}
+
+	coefficient := numerator / denominator
+
+	return Result{
+		Name:      "serial correlation",
+		Statistic: coefficient,
+		PValue:    math.Erfc(math.Abs(coefficient) * math.Sqrt(float64(n)) / math.Sqrt2),
+	}, nil
+}
+
+// Runs performs the Wald-Wolfowitz runs test for independence: values are
+// classified as above or below the median and the number of runs of equal
+// classification is compared with its expectation. Values equal to the median
+// are ignored.
+func Runs(values []float64) (Result, error) {
+	median := medianOf(values)
+
+	var (
+		above, below, runs int
+		last               int
+	)
+
+	for _, v := range values {
+		side := 0
+
+		switch {
+		case v > median:
+			side = 1
+			above++
+		case v < median:
+			side = -1
+			below++
+		default:
+			continue
+		}
+
+		if side != last {
+			runs++
+			last = side
+		}
+	}
+
+	if above < 10 || below < 10 {
+		return Result{}, fmt.Errorf("%w: need at least 10 values on each side of the median", ErrInsufficientData)
+	}
+
+	n1, n2 := float64(above), float64(below)
+	n := n1 + n2
+	mean := 2*n1*n2/n + 1
+	variance := 2 * n1 * n2 * (2*n1*n2 - n) / (n * n * (n - 1))
+	z := (float64(runs) - mean) / math.Sqrt(variance)
+
+	return Result{
+		Name:      "runs",
+		Statistic: z,
+		PValue:    math.Erfc(math.Abs(z) / math.Sqrt2),
+	}, nil
+}
+
+// Monobit performs the NIST SP 800-22 frequency test: the proportion of one bits
+// in data should be close to one half.
+//
+// It applies to raw random bytes, not to characters drawn from a charset, whose
+// bit patterns are fixed by the charset.
+func Monobit(data []byte) (Result, error) {
+	if len(data) < 13 {
+		return Result{}, fmt.Errorf("%w: need at least 100 bits", ErrInsufficientData)
+	}
+
+	sum := 0
+	for _, b := range data {
+		for bit := range 8 {
+			sum += int(b>>bit&1)*2 - 1
+		}
+	}
+
+	statistic := math.Abs(float64(sum)) / math.Sqrt(float64(len(data)*8))
+
+	return Result{
+		Name:      "monobit",
+		Statistic: statistic,
+		PValue:    math.Erfc(statistic / math.Sqrt2),
+	}, nil
+}
+
+// medianOf returns the median of values without modifying them.
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
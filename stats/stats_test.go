@@ -0,0 +1,159 @@
+package stats_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/everlastingbeta/strand/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChiSquaredPValues verifies p-values against critical values from
+// standard chi-squared tables.
+func TestChiSquaredPValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		degrees  int     // Degrees of freedom
+		critical float64 // Critical value of the statistic
+		p        float64 // Upper tail probability at the critical value
+	}{
+		{1, 3.841, 0.05},
+		{1, 6.635, 0.01},
+		{5, 11.070, 0.05},
+		{10, 18.307, 0.05},
+		{10, 2.558, 0.99},
+		{30, 50.892, 0.01},
+		{100, 124.342, 0.05},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.p, stats.ChiSquaredPValue(tt.critical, tt.degrees), 1e-4, "df=%d", tt.degrees)
+	}
+
+	// Perfect agreement with the expectation is never evidence of bias.
+	result, err := stats.ChiSquared([]int{10, 10}, []float64{10, 10})
+	require.NoError(t, err)
+	assert.InDelta(t, 1, result.PValue, 1e-12)
+}
+
+// TestChiSquaredUniform verifies detection of uniform and biased samples.
+func TestChiSquaredUniform(t *testing.T) {
+	t.Parallel()
+
+	uniform := bytes.Repeat([]byte("abcd"), 1000)
+
+	result, err := stats.ChiSquaredUniform(uniform, "abcd")
+	require.NoError(t, err)
+	assert.InDelta(t, 0, result.Statistic, 1e-12)
+	assert.True(t, result.Pass(0.01))
+
+	biased := append(bytes.Repeat([]byte("a"), 2000), bytes.Repeat([]byte("bcd"), 666)...)
+
+	result, err = stats.ChiSquaredUniform(biased, "abcd")
+	require.NoError(t, err)
+	assert.False(t, result.Pass(1e-6), result.String())
+
+	// A duplicated character is expected twice as often.
+	result, err = stats.ChiSquaredUniform(bytes.Repeat([]byte("aab"), 1000), "aab")
+	require.NoError(t, err)
+	assert.True(t, result.Pass(0.01))
+
+	_, err = stats.ChiSquaredUniform([]byte("abz"), "ab")
+	require.ErrorIs(t, err, stats.ErrOutOfCharset)
+
+	_, err = stats.ChiSquaredUniform([]byte("ab"), "ab")
+	require.ErrorIs(t, err, stats.ErrInsufficientData)
+
+	_, err = stats.ChiSquared([]int{1, 2}, []float64{1})
+	require.ErrorIs(t, err, stats.ErrLengthMismatch)
+}
+
+// TestSerialCorrelation verifies detection of correlated sequences.
+func TestSerialCorrelation(t *testing.T) {
+	t.Parallel()
+
+	ramp := make([]float64, 1000)
+	for i := range ramp {
+		ramp[i] = float64(i % 100)
+	}
+
+	result, err := stats.SerialCorrelation(ramp)
+	require.NoError(t, err)
+	assert.Greater(t, result.Statistic, 0.9)
+	assert.False(t, result.Pass(1e-6))
+
+	alternating := make([]float64, 1000)
+	for i := range alternating {
+		alternating[i] = float64(i % 2)
+	}
+
+	result, err = stats.SerialCorrelation(alternating)
+	require.NoError(t, err)
+	assert.InDelta(t, -1, result.Statistic, 1e-9)
+
+	_, err = stats.SerialCorrelation(ramp[:10])
+	require.ErrorIs(t, err, stats.ErrInsufficientData)
+}
+
+// TestRuns verifies detection of too few and too many runs.
+func TestRuns(t *testing.T) {
+	t.Parallel()
+
+	clustered := make([]float64, 1000)
+	for i := range clustered {
+		clustered[i] = float64(i / 500)
+	}
+
+	result, err := stats.Runs(clustered)
+	require.NoError(t, err)
+	assert.Less(t, result.Statistic, 0.0)
+	assert.False(t, result.Pass(1e-6))
+
+	alternating := make([]float64, 1000)
+	for i := range alternating {
+		alternating[i] = float64(i % 2)
+	}
+
+	result, err = stats.Runs(alternating)
+	require.NoError(t, err)
+	assert.Greater(t, result.Statistic, 0.0)
+	assert.False(t, result.Pass(1e-6))
+
+	_, err = stats.Runs([]float64{1, 1, 1})
+	require.ErrorIs(t, err, stats.ErrInsufficientData)
+}
+
+// TestMonobit verifies detection of balanced and skewed bits.
+func TestMonobit(t *testing.T) {
+	t.Parallel()
+
+	// Exactly half of the bits are set, the best possible outcome.
+	balanced := bytes.Repeat([]byte{0x0f}, 100)
+
+	result, err := stats.Monobit(balanced)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, result.PValue, 1e-12)
+
+	skewed := bytes.Repeat([]byte{0x1f}, 100)
+
+	result, err = stats.Monobit(skewed)
+	require.NoError(t, err)
+	assert.False(t, result.Pass(1e-6))
+
+	_, err = stats.Monobit([]byte{1, 2, 3})
+	require.ErrorIs(t, err, stats.ErrInsufficientData)
+}
+
+// TestIndexes verifies the mapping from characters to charset positions.
+func TestIndexes(t *testing.T) {
+	t.Parallel()
+
+	values, err := stats.Indexes([]byte("cab"), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 0, 1}, values)
+
+	_, err = stats.Indexes([]byte("x"), "abc")
+	require.ErrorIs(t, err, stats.ErrOutOfCharset)
+}
@@ -2,9 +2,7 @@ package strand
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
-	"fmt"
 )
 
 // Common error types for the strand package.
@@ -73,30 +71,7 @@ func Bytes(size int, charset string) ([]byte, error) {
 //
 // This function uses crypto/rand and is suitable for security-sensitive applications.
 func BytesWithContext(ctx context.Context, size int, charset string) ([]byte, error) {
-	select {
-	case <-ctx.Done():
-		return nil, fmt.Errorf("failed to created secure random bytes due to context ending early: %w", ctx.Err())
-	default:
-		if size <= 0 {
-			return nil, ErrInvalidSize
-		}
-
-		if len(charset) == 0 {
-			return nil, ErrEmptyCharset
-		}
-
-		nonce := make([]byte, size)
-		if _, err := rand.Read(nonce); err != nil {
-			return nil, fmt.Errorf("%w: %w", ErrRandomFailure, err)
-		}
-
-		charsetLen := byte(len(charset))
-		for i, b := range nonce {
-			nonce[i] = charset[b%charsetLen]
-		}
-
-		return nonce, nil
-	}
+	return defaultGenerator().BytesWithContext(ctx, size, charset)
 }
 
 // String generates a cryptographically secure random string using characters
@@ -0,0 +1,212 @@
+// Package strandtest provides sources of randomness and assertion helpers for
+// testing code that generates values with strand.
+//
+// Code under test should accept a *strand.Generator, which production code
+// creates from crypto/rand.Reader and tests create from one of the sources in
+// this package:
+//
+//	gen := strand.NewGenerator(strandtest.NewScriptedSource(strandtest.ScriptFor(t, strand.Numbers, "1234")))
+//	pin, _ := gen.String(4, strand.Numbers) // "1234"
+package strandtest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+)
+
+// ErrSourceExhausted is returned by a ScriptedSource once every scripted byte
+// has been read.
+var ErrSourceExhausted = errors.New("strandtest: scripted source exhausted")
+
+// maxScriptedCharset is the longest charset for which a strand.Generator maps
+// each byte it reads to one character.
+const maxScriptedCharset = 256
+
+// ScriptedSource is a source that returns a fixed sequence of bytes, either
+// once or cyclically. It is safe for concurrent use.
+type ScriptedSource struct {
+	mu     sync.Mutex
+	script []byte
+	off    int
+	cycle  bool
+}
+
+// NewScriptedSource returns a source that yields the concatenation of chunks
+// once and then fails with ErrSourceExhausted.
+func NewScriptedSource(chunks ...[]byte) *ScriptedSource {
+	return &ScriptedSource{script: concat(chunks)}
+}
+
+// NewCyclicSource returns a source that yields the concatenation of chunks
+// over and over. It panics if chunks contain no bytes.
+//
+// A strand.Generator redraws bytes at or above the largest multiple of the
+// charset length that fits in a byte, so a script made only of such bytes makes
+// it loop forever. Scripts built with ScriptFor never contain them.
+func NewCyclicSource(chunks ...[]byte) *ScriptedSource {
+	script := concat(chunks)
+	if len(script) == 0 {
+		panic("strandtest: cyclic source needs at least one byte")
+	}
+
+	return &ScriptedSource{script: script, cycle: true}
+}
+
+// NewConstantSource returns a source that makes a strand.Generator draw
+// charset[index] for every character, forever. For raw bytes, such as those of
+// a token, use NewCyclicSource instead.
+//
+// It panics if index is outside charset or if charset is longer than 256
+// characters, since the Generator then draws characters from more than one byte.
+func NewConstantSource(charset string, index int) *ScriptedSource {
+	if len(charset) > maxScriptedCharset {
+		panic("strandtest: constant source needs a charset of at most 256 characters")
+	}
+
+	if index < 0 || index >= len(charset) {
+		panic("strandtest: constant source index is outside the charset")
+	}
+
+	return NewCyclicSource([]byte{byte(index)})
+}
+
+// Read copies the next scripted bytes into p.
+func (s *ScriptedSource) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for n < len(p) {
+		if s.off == len(s.script) {
+			if !s.cycle {
+				return n, ErrSourceExhausted
+			}
+
+			s.off = 0
+		}
+
+		copied := copy(p[n:], s.script[s.off:])
+		s.off += copied
+		n += copied
+	}
+
+	return n, nil
+}
+
+// Remaining returns the number of scripted bytes not yet read. It is always
+// zero for cyclic sources once a full cycle has been read.
+func (s *ScriptedSource) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.script) - s.off
+}
+
+// FailingSource is a source whose every read fails.
+type FailingSource struct {
+	err error
+}
+
+// NewFailingSource returns a source whose reads fail with strand.ErrRandomFailure,
+// or with err if given, to exercise error paths of code under test.
+func NewFailingSource(err ...error) *FailingSource {
+	if len(err) > 0 {
+		return &FailingSource{err: err[0]}
+	}
+
+	return &FailingSource{err: strand.ErrRandomFailure}
+}
+
+// Read always fails.
+func (s *FailingSource) Read([]byte) (int, error) {
+	return 0, s.err
+}
+
+// ScriptFor returns the bytes that make a strand.Generator produce want when
+// drawing len(want) characters from charset. It fails the test if want contains
+// a character outside the charset, or if charset is longer than 256 characters,
+// since the Generator then draws characters from more than one byte.
+func ScriptFor(tb testing.TB, charset, want string) []byte {
+	tb.Helper()
+
+	if len(charset) > maxScriptedCharset {
+		tb.Fatalf("strandtest: cannot script a charset of %d characters, the maximum is %d", len(charset), maxScriptedCharset)
+
+		return nil
+	}
+
+	script := make([]byte, len(want))
+	for i := range len(want) {
+		index := strings.IndexByte(charset, want[i])
+		if index < 0 {
+			tb.Fatalf("strandtest: %q at position %d is not in charset %q", want[i], i, charset)
+		}
+
+		script[i] = byte(index)
+	}
+
+	return script
+}
+
+// AssertFromCharset reports whether every character of s belongs to charset,
+// marking the test as failed if not.
+func AssertFromCharset(tb testing.TB, s, charset string) bool {
+	tb.Helper()
+
+	for i := range len(s) {
+		if strings.IndexByte(charset, s[i]) < 0 {
+			tb.Errorf("strandtest: %q at position %d of %q is not in charset %q", s[i], i, s, charset)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// AssertLen reports whether s has exactly size bytes, marking the test as
+// failed if not.
+func AssertLen(tb testing.TB, s string, size int) bool {
+	tb.Helper()
+
+	if len(s) != size {
+		tb.Errorf("strandtest: %q has length %d, want %d", s, len(s), size)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertUnique reports whether all values are distinct, marking the test as
+// failed if not.
+func AssertUnique(tb testing.TB, values []string) bool {
+	tb.Helper()
+
+	seen := make(map[string]int, len(values))
+	for i, v := range values {
+		if first, ok := seen[v]; ok {
+			tb.Errorf("strandtest: %q at index %d duplicates index %d", v, i, first)
+
+			return false
+		}
+
+		seen[v] = i
+	}
+
+	return true
+}
+
+// concat joins chunks into a single script.
+func concat(chunks [][]byte) []byte {
+	var script []byte
+	for _, chunk := range chunks {
+		script = append(script, chunk...)
+	}
+
+	return script
+}
@@ -0,0 +1,132 @@
+package strandtest_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/everlastingbeta/strand/strandtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder captures failures reported by the assertion helpers.
+type recorder struct {
+	testing.TB
+
+	failures []string
+}
+
+// Helper is a no-op so that failures are attributed to the recorder.
+func (r *recorder) Helper() {}
+
+// Errorf records a failure instead of failing the test.
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records a failure instead of stopping the test.
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+// TestScriptedSource verifies that scripted bytes drive generator output.
+func TestScriptedSource(t *testing.T) {
+	t.Parallel()
+
+	source := strandtest.NewScriptedSource(strandtest.ScriptFor(t, strand.Numbers, "1234"), []byte{0, 1})
+	gen := strand.NewGenerator(source)
+
+	pin, err := gen.String(4, strand.Numbers)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", pin)
+	assert.Equal(t, 2, source.Remaining())
+
+	letters, err := gen.String(2, strand.UppercaseAlphabet)
+	require.NoError(t, err)
+	assert.Equal(t, "AB", letters)
+	assert.Zero(t, source.Remaining())
+
+	_, err = gen.String(1, strand.Numbers)
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+	require.ErrorIs(t, err, strandtest.ErrSourceExhausted)
+}
+
+// TestCyclicAndConstantSources verifies that repeating sources never run out.
+func TestCyclicAndConstantSources(t *testing.T) {
+	t.Parallel()
+
+	cyclic, err := strand.NewGenerator(strandtest.NewCyclicSource([]byte{0, 1, 2})).String(7, strand.LowercaseAlphabet)
+	require.NoError(t, err)
+	assert.Equal(t, "abcabca", cyclic)
+
+	constant, err := strand.NewGenerator(strandtest.NewConstantSource(strand.UppercaseAlphabet, 25)).String(5, strand.UppercaseAlphabet)
+	require.NoError(t, err)
+	assert.Equal(t, "ZZZZZ", constant)
+
+	token, err := strand.NewGenerator(strandtest.NewCyclicSource([]byte{0xab})).Token(2, strand.Hex)
+	require.NoError(t, err)
+	assert.Equal(t, "abab", token)
+
+	assert.Panics(t, func() {
+		strandtest.NewCyclicSource()
+	})
+	assert.Panics(t, func() {
+		strandtest.NewConstantSource(strand.Numbers, 10)
+	})
+	assert.Panics(t, func() {
+		strandtest.NewConstantSource(strings.Repeat(strand.Numbers, 26)+"!", 0)
+	})
+}
+
+// TestRejectedBytes verifies that a Generator skips scripted bytes that would
+// bias the charset mapping, and that scripts never contain them.
+func TestRejectedBytes(t *testing.T) {
+	t.Parallel()
+
+	// For the 10 digits, bytes 250-255 are redrawn.
+	source := strandtest.NewCyclicSource([]byte{255, 250, 3})
+
+	pin, err := strand.NewGenerator(source).String(4, strand.Numbers)
+	require.NoError(t, err)
+	assert.Equal(t, "3333", pin)
+
+	for _, b := range strandtest.ScriptFor(t, strand.Numbers, "9876543210") {
+		assert.Less(t, b, byte(250))
+	}
+}
+
+// TestFailingSource verifies that failures surface as strand.ErrRandomFailure.
+func TestFailingSource(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.NewGenerator(strandtest.NewFailingSource()).String(8, strand.ALL)
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+
+	custom := errors.New("entropy pool drained")
+
+	_, err = strand.NewGenerator(strandtest.NewFailingSource(custom)).Bytes(8, strand.ALL)
+	require.ErrorIs(t, err, strand.ErrRandomFailure)
+	require.ErrorIs(t, err, custom)
+}
+
+// TestAssertions verifies that the assertion helpers pass and fail as documented.
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, strandtest.AssertFromCharset(t, "abc", strand.LowercaseAlphabet))
+	assert.True(t, strandtest.AssertLen(t, "abc", 3))
+	assert.True(t, strandtest.AssertUnique(t, []string{"a", "b", "c"}))
+
+	r := &recorder{TB: t}
+
+	assert.False(t, strandtest.AssertFromCharset(r, "ab1", strand.LowercaseAlphabet))
+	assert.False(t, strandtest.AssertLen(r, "abc", 4))
+	assert.False(t, strandtest.AssertUnique(r, []string{"a", "b", "a"}))
+	strandtest.ScriptFor(r, strand.Numbers, "12x")
+	strandtest.ScriptFor(r, strings.Repeat(strand.Numbers, 26)+"!", "1")
+
+	assert.Len(t, r.failures, 5)
+}
@@ -0,0 +1,276 @@
+package strand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidWeight is returned when a weight is negative, not finite, or when
+// all weights are zero.
+var ErrInvalidWeight = errors.New("invalid weight: must be finite, non-negative and not all zero")
+
+// WeightedCharset assigns a relative weight to a charset. The weight is shared
+// evenly by the characters of the charset, so a single-character charset sets
+// the weight of that character alone.
+type WeightedCharset struct {
+	Charset string
+	Weight  float64
+}
+
+// Weighted describes a non-uniform distribution over characters, such as
+// "mostly letters, some digits":
+//
+//	strand.Weighted{{strand.Alphabet, 8}, {strand.Numbers, 2}}
+//
+// draws a letter 80% of the time and a digit 20% of the time. Weights are
+// relative and need not sum to any particular value. A character appearing in
+// several entries receives the sum of its shares.
+//
+// Characters are drawn with Vose's alias method, so every draw takes constant
+// time regardless of the number of characters.
+type Weighted []WeightedCharset
+
+// Bytes generates a cryptographically secure random byte slice whose characters
+// follow the weighted distribution.
+//
+// Parameters:
+//   - size: the length of the byte slice to be returned. Must be greater than 0.
+//
+// Returns:
+//   - []byte: a randomly generated byte slice of the specified size.
+//   - error: an error if random generation fails or if the weights are invalid.
+func (w Weighted) Bytes(size int) ([]byte, error) {
+	return w.BytesWithContext(context.Background(), size)
+}
+
+// BytesWithContext works like Bytes but accepts a context for cancellation support.
+func (w Weighted) BytesWithContext(ctx context.Context, size int) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to generate weighted bytes due to context ending early: %w", ctx.Err())
+	default:
+		if size <= 0 {
+			return nil, ErrInvalidSize
+		}
+
+		return w.generate(newCryptoSource(), size)
+	}
+}
+
+// String generates a cryptographically secure random string whose characters
+// follow the weighted distribution.
+func (w Weighted) String(size int) (string, error) {
+	return w.StringWithContext(context.Background(), size)
+}
+
+// StringWithContext works like String but accepts a context for cancellation support.
+func (w Weighted) StringWithContext(ctx context.Context, size int) (string, error) {
+	nonce, err := w.BytesWithContext(ctx, size)
+	if err != nil {
+		return "", err
+	}
+
+	return string(nonce), nil
+}
+
+// SeededBytes returns a deterministic byte slice whose characters follow the
+// weighted distribution.
+//
+// Parameters:
+//   - size: the length of the byte slice to be returned.
+//   - seed: optional int64 value to initialize the random source. If omitted,
+//     time.Now().UnixNano() will be used as the default seed.
+//
+// Returns an error only if the weights are invalid.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use Bytes() instead.
+func (w Weighted) SeededBytes(size int, seed ...int64) ([]byte, error) {
+	if size <= 0 {
+		return []byte{}, nil
+	}
+
+	return w.generate(seededSource{rng: newSeededRand(seed...)}, size)
+}
+
+// SeededString works like SeededBytes but returns a string.
+//
+// Security Notice: This function uses math/rand/v2 which is NOT cryptographically
+// secure. For security-sensitive applications, use String() instead.
+func (w Weighted) SeededString(size int, seed ...int64) (string, error) {
+	nonce, err := w.SeededBytes(size, seed...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(nonce), nil
+}
+
+// Probabilities returns the probability of drawing each character.
+func (w Weighted) Probabilities() (map[byte]float64, error) {
+	chars, weights, err := w.flatten()
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	probabilities := make(map[byte]float64, len(chars))
+	for i, c := range chars {
+		probabilities[c] = weights[i] / total
+	}
+
+	return probabilities, nil
+}
+
+// generate draws size characters from the distribution using src.
+func (w Weighted) generate(src source, size int) ([]byte, error) {
+	table, err := w.aliasTable()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, size)
+	for i := range nonce {
+		if nonce[i], err = table.draw(src); err != nil {
+			return nil, err
+		}
+	}
+
+	return nonce, nil
+}
+
+// flatten validates the entries and merges them into one weight per distinct
+// character, in order of first appearance.
+func (w Weighted) flatten() ([]byte, []float64, error) {
+	if len(w) == 0 {
+		return nil, nil, ErrEmptyCharset
+	}
+
+	var (
+		chars    []byte
+		weights  []float64
+		position [256]int
+		total    float64
+	)
+
+	for _, entry := range w {
+		if entry.Charset == "" {
+			return nil, nil, ErrEmptyCharset
+		}
+
+		if entry.Weight < 0 || math.IsNaN(entry.Weight) || math.IsInf(entry.Weight, 0) {
+			return nil, nil, fmt.Errorf("%w: %v for %q", ErrInvalidWeight, entry.Weight, entry.Charset)
+		}
+
+		share := entry.Weight / float64(len(entry.Charset))
+		for i := range len(entry.Charset) {
+			c := entry.Charset[i]
+			if position[c] == 0 {
+				chars = append(chars, c)
+				weights = append(weights, 0)
+				position[c] = len(chars)
+			}
+
+			weights[position[c]-1] += share
+		}
+
+		total += entry.Weight
+	}
+
+	if total == 0 || math.IsInf(total, 0) {
+		return nil, nil, ErrInvalidWeight
+	}
+
+	return chars, weights, nil
+}
+
+// aliasTable builds the alias table for the distribution.
+func (w Weighted) aliasTable() (*aliasTable, error) {
+	chars, weights, err := w.flatten()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(chars)
+	total := 0.0
+
+	for _, weight := range weights {
+		total += weight
+	}
+
+	table := &aliasTable{
+		chars: chars,
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale the probabilities so that the average column height is 1, then pair
+	// every short column with a tall one that fills its remainder.
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+
+	for i, weight := range weights {
+		scaled[i] = weight * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		table.prob[s] = scaled[s]
+		table.alias[s] = l
+
+		scaled[l] += scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// Whatever remains is full up to floating point error.
+	for _, i := range append(small, large...) {
+		table.prob[i] = 1
+	}
+
+	return table, nil
+}
+
+// aliasTable implements Vose's alias method: a column is chosen uniformly and
+// then either its own character or its alias is returned.
+type aliasTable struct {
+	chars []byte
+	prob  []float64
+	alias []int
+}
+
+// draw returns a single character using src.
+func (t *aliasTable) draw(src source) (byte, error) {
+	column, err := src.intN(len(t.chars))
+	if err != nil {
+		return 0, err
+	}
+
+	u, err := src.float64()
+	if err != nil {
+		return 0, err
+	}
+
+	if u < t.prob[column] {
+		return t.chars[column], nil
+	}
+
+	return t.chars[t.alias[column]], nil
+}
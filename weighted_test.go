@@ -0,0 +1,142 @@
+package strand_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/everlastingbeta/strand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWeightedGenerate verifies that weighted output only uses the configured
+// characters, for both the crypto and seeded sources.
+func TestWeightedGenerate(t *testing.T) {
+	t.Parallel()
+
+	w := strand.Weighted{{strand.Alphabet, 8}, {strand.Numbers, 2}}
+
+	b, err := w.Bytes(64)
+	require.NoError(t, err)
+	assert.Len(t, b, 64)
+	assert.True(t, onlyContains(string(b), strand.AlphaNumeric))
+
+	s, err := w.String(64)
+	require.NoError(t, err)
+	assert.Len(t, s, 64)
+	assert.True(t, onlyContains(s, strand.AlphaNumeric))
+
+	seeded, err := w.SeededString(64, 42)
+	require.NoError(t, err)
+	assert.True(t, onlyContains(seeded, strand.AlphaNumeric))
+
+	again, err := w.SeededString(64, 42)
+	require.NoError(t, err)
+	assert.Equal(t, seeded, again, "Same seed should produce same output")
+}
+
+// TestWeightedDistribution verifies that draws follow the configured
+// probabilities.
+func TestWeightedDistribution(t *testing.T) {
+	t.Parallel()
+
+	const samples = 200_000
+
+	tests := []struct {
+		name     string             // Description of the test case
+		weighted strand.Weighted    // Distribution under test
+		want     map[string]float64 // Expected frequency of each group of characters
+	}{
+		{
+			name:     "per-class weights",
+			weighted: strand.Weighted{{strand.Alphabet, 8}, {strand.Numbers, 2}},
+			want:     map[string]float64{strand.Alphabet: 0.8, strand.Numbers: 0.2},
+		},
+		{
+			name:     "per-character weights",
+			weighted: strand.Weighted{{"a", 1}, {"b", 2}, {"c", 7}},
+			want:     map[string]float64{"a": 0.1, "b": 0.2, "c": 0.7},
+		},
+		{
+			name:     "overlapping entries add up",
+			weighted: strand.Weighted{{"ab", 2}, {"b", 2}},
+			want:     map[string]float64{"a": 0.25, "b": 0.75},
+		},
+		{
+			name:     "zero weight is never drawn",
+			weighted: strand.Weighted{{"x", 0}, {"y", 1}},
+			want:     map[string]float64{"x": 0, "y": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, generate := range []func() (string, error){
+				func() (string, error) { return tt.weighted.String(samples) },
+				func() (string, error) { return tt.weighted.SeededString(samples, 7) },
+			} {
+				value, err := generate()
+				require.NoError(t, err)
+
+				for group, want := range tt.want {
+					count := 0
+
+					for i := range len(value) {
+						if strings.IndexByte(group, value[i]) >= 0 {
+							count++
+						}
+					}
+
+					got := float64(count) / samples
+					// Allow six standard deviations of sampling error.
+					tolerance := 6 * math.Sqrt(want*(1-want)/samples)
+					assert.InDelta(t, want, got, tolerance, "group %q", group)
+				}
+			}
+		})
+	}
+}
+
+// TestWeightedProbabilities verifies the computed per-character probabilities.
+func TestWeightedProbabilities(t *testing.T) {
+	t.Parallel()
+
+	probabilities, err := strand.Weighted{{"ab", 2}, {"c", 2}}.Probabilities()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, probabilities['a'], 1e-12)
+	assert.InDelta(t, 0.25, probabilities['b'], 1e-12)
+	assert.InDelta(t, 0.5, probabilities['c'], 1e-12)
+}
+
+// TestWeightedErrors verifies that invalid distributions are rejected.
+func TestWeightedErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := strand.Weighted{}.String(10)
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.Weighted{{"", 1}}.String(10)
+	require.ErrorIs(t, err, strand.ErrEmptyCharset)
+
+	_, err = strand.Weighted{{"a", -1}}.String(10)
+	require.ErrorIs(t, err, strand.ErrInvalidWeight)
+
+	_, err = strand.Weighted{{"a", math.NaN()}}.SeededString(10, 42)
+	require.ErrorIs(t, err, strand.ErrInvalidWeight)
+
+	_, err = strand.Weighted{{"a", 0}, {"b", 0}}.String(10)
+	require.ErrorIs(t, err, strand.ErrInvalidWeight)
+
+	_, err = strand.Weighted{{"a", 1}}.String(0)
+	require.ErrorIs(t, err, strand.ErrInvalidSize)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = strand.Weighted{{"a", 1}}.StringWithContext(ctx, 10)
+	require.ErrorIs(t, err, context.Canceled)
+}